@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
@@ -14,11 +15,12 @@ import (
 
 func newActivitiesCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "activities [alias_id] [page_id]",
-		Aliases: []string{"act"},
-		Short:   "List alias activities",
-		Long:    activitiesDescription,
-		Args:    cobra.ExactArgs(2),
+		Use:               "activities [alias_id] [page_id]",
+		Aliases:           []string{"act"},
+		Short:             "List alias activities",
+		Long:              activitiesDescription,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completion.FirstArg(completion.AliasIDs),
 		Run: func(cmd *cobra.Command, args []string) {
 			runActivities(outputFormat, args)
 		},
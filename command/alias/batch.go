@@ -0,0 +1,76 @@
+package alias
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// batchNoteKey is the note metadata key identifying the batch an alias belongs to
+const batchNoteKey = "batch"
+
+// BatchResult represents the outcome for one alias of a batch
+type BatchResult struct {
+	N     int    `json:"n"`
+	Email string `json:"email"`
+	ID    int    `json:"id,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// parseVars turns key=value flags into a map
+func parseVars(vars []string) (map[string]string, error) {
+	result := make(map[string]string, len(vars))
+	for _, v := range vars {
+		key, value, ok := strings.Cut(v, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid variable %q, expected key=value", v)
+		}
+		result[key] = value
+	}
+	return result, nil
+}
+
+// writeBatchResults writes the results as CSV or JSON, to a file when path is
+// set (the format follows its extension) or to stdout
+func writeBatchResults(results []BatchResult, path, outputFormat string) error {
+	var w io.Writer = os.Stdout
+	format := outputFormat
+
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		w = f
+		format = "csv"
+		if strings.EqualFold(filepath.Ext(path), ".json") {
+			format = "json"
+		}
+	}
+
+	if format == "json" {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(results)
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"n", "email", "id", "error"})
+	for _, result := range results {
+		id := ""
+		if result.ID != 0 {
+			id = strconv.Itoa(result.ID)
+		}
+		writer.Write([]string{strconv.Itoa(result.N), result.Email, id, result.Error})
+	}
+	writer.Flush()
+
+	return writer.Error()
+}
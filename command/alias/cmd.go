@@ -22,13 +22,22 @@ func NewCommand(outputFormat *string) *cobra.Command {
 	cmd.AddCommand(
 		newActivitiesCommand(outputFormat),
 		newCreateNewCommand(outputFormat),
+		newCreateBatchCommand(outputFormat),
 		newCreateRandomCommand(outputFormat),
+		newDeleteBatchCommand(outputFormat),
 		newDeleteCommand(outputFormat),
+		newExecCommand(),
+		newExpireCommand(outputFormat),
+		newForCommand(outputFormat),
 		newGetCommand(outputFormat),
+		newImportCommand(outputFormat),
 		newListCommand(outputFormat),
 		newOptionsCommand(outputFormat),
+		newReconcileCommand(outputFormat),
+		newTagCommand(outputFormat),
 		newToggleCommand(outputFormat),
 		newUpdateCommand(),
+		newWaitMailCommand(outputFormat),
 	)
 
 	return cmd
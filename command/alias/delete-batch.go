@@ -0,0 +1,234 @@
+package alias
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/batch"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/notemeta"
+	"github.com/juli3nk/simplelogin-cli/internal/prompt"
+	"github.com/juli3nk/simplelogin-cli/internal/protect"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteBatchTemplate    string
+	deleteBatchVars        []string
+	deleteBatchSuffix      string
+	deleteBatchID          string
+	deleteBatchTags        []string
+	deleteBatchConcurrency int
+	deleteBatchRate        int
+	deleteBatchDryRun      bool
+	deleteBatchOut         string
+	deleteBatchYes         bool
+)
+
+func newDeleteBatchCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "delete-batch",
+		Short: "Delete a batch of aliases",
+		Long:  deleteBatchDescription,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runDeleteBatch(outputFormat)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&deleteBatchTemplate, "template", "t", "", "Delete aliases whose prefix matches this template")
+	flags.StringArrayVar(&deleteBatchVars, "var", []string{}, "Template variable as key=value (repeatable)")
+	flags.StringVarP(&deleteBatchSuffix, "suffix", "s", "", "Only delete aliases with this suffix, e.g. @example.com")
+	flags.StringVar(&deleteBatchID, "batch", "", "Delete aliases whose note has this batch marker")
+	flags.StringSliceVar(&deleteBatchTags, "tag", []string{}, "Delete aliases with this tag (repeatable)")
+	flags.IntVar(&deleteBatchConcurrency, "concurrency", 4, "Number of aliases deleted in parallel")
+	flags.IntVar(&deleteBatchRate, "rate", 0, "Maximum API requests per minute (0 for no limit)")
+	flags.BoolVar(&deleteBatchDryRun, "dry-run", false, "Only list the aliases that would be deleted")
+	flags.StringVar(&deleteBatchOut, "out", "", "Write results to this file (.csv or .json) instead of stdout")
+	flags.BoolVarP(&deleteBatchYes, "yes", "y", false, "Do not ask for confirmation")
+
+	cmd.RegisterFlagCompletionFunc("suffix", completion.DomainSuffixes)
+
+	return cmd
+}
+
+func runDeleteBatch(outputFormat *string) {
+	defer utils.RecoverFunc()
+
+	if deleteBatchTemplate == "" && deleteBatchID == "" && len(deleteBatchTags) == 0 {
+		log.Fatal("--template, --batch or --tag is required")
+	}
+
+	vars, err := parseVars(deleteBatchVars)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var tmpl *batch.Template
+	if deleteBatchTemplate != "" {
+		if tmpl, err = batch.Parse(deleteBatchTemplate); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	query := deleteBatchID
+	if tmpl != nil {
+		query = tmpl.LiteralPrefix()
+	}
+
+	aliases, err := client.GetAllAliases(simplelogin.AliasListOptions{Query: query})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	matches, err := matchBatch(filterTagged(aliases, deleteBatchTags), tmpl, vars)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rules, err := protect.New(cfg.Protect)
+	if err != nil {
+		log.Fatal(err)
+	}
+	matches = skipProtected(rules, matches)
+
+	if deleteBatchDryRun {
+		for _, alias := range matches {
+			fmt.Println(alias.Email)
+		}
+		fmt.Fprintf(os.Stderr, "%d aliases would be deleted\n", len(matches))
+		return
+	}
+
+	if len(matches) > 0 {
+		if !deleteBatchYes && prompt.IsTerminal() {
+			printBatchSummary(matches)
+		}
+		if err := prompt.ConfirmAction(fmt.Sprintf("Delete %d aliases?", len(matches)), deleteBatchYes); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	client.SetRateLimit(deleteBatchRate, time.Minute)
+
+	jobs := make(chan int)
+	results := make([]BatchResult, len(matches))
+
+	var wg sync.WaitGroup
+	for w := 0; w < max(deleteBatchConcurrency, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = BatchResult{N: i + 1, Email: matches[i].Email, ID: matches[i].ID}
+				if _, err := client.DeleteAlias(matches[i].ID); err != nil {
+					results[i].Error = err.Error()
+				}
+			}
+		}()
+	}
+
+	for i := range matches {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := writeBatchResults(results, deleteBatchOut, *outputFormat); err != nil {
+		log.Fatal(err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "%d deleted, %d failed\n", len(results)-failed, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// printBatchSummary lists the aliases a batch deletion destroys
+func printBatchSummary(aliases []simplelogin.Alias) {
+	for _, alias := range aliases {
+		fmt.Fprintf(os.Stderr, "%s (ID %d): %d forwarded\n", alias.Email, alias.ID, alias.NbForward)
+	}
+	fmt.Fprintln(os.Stderr, "The aliases and their contacts are deleted, emails sent to them will bounce.")
+}
+
+// matchBatch keeps the aliases matching the batch marker, template and suffix
+func matchBatch(aliases []simplelogin.Alias, tmpl *batch.Template, vars map[string]string) ([]simplelogin.Alias, error) {
+	var matches []simplelogin.Alias
+
+	for _, alias := range aliases {
+		if deleteBatchID != "" {
+			if marker, ok := notemeta.Get(alias.Note, batchNoteKey); !ok || marker != deleteBatchID {
+				continue
+			}
+		}
+
+		if deleteBatchSuffix != "" && !strings.HasSuffix(strings.ToLower(alias.Email), strings.ToLower(deleteBatchSuffix)) {
+			continue
+		}
+
+		if tmpl != nil {
+			re, err := tmpl.Regexp(vars)
+			if err != nil {
+				return nil, err
+			}
+
+			prefix := alias.Email
+			if deleteBatchSuffix != "" {
+				prefix = prefix[:len(prefix)-len(deleteBatchSuffix)]
+			} else if i := strings.LastIndex(prefix, "@"); i >= 0 {
+				prefix = prefix[:i]
+			}
+
+			if !re.MatchString(prefix) {
+				continue
+			}
+		}
+
+		matches = append(matches, alias)
+	}
+
+	return matches, nil
+}
+
+const deleteBatchDescription = `
+Delete a batch of aliases
+
+Aliases are selected by the "#batch: <marker>" line written in their note by
+'simplelogin-cli alias new-batch' (--batch), by template (--template and
+--var), by tag (--tag), or a combination. Use --dry-run to review the selection first.
+
+The selected aliases are listed and a confirmation asked before deleting
+them; pass --yes in scripts.
+
+`
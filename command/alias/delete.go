@@ -3,27 +3,36 @@ package alias
 import (
 	"fmt"
 	"log"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/prompt"
+	"github.com/juli3nk/simplelogin-cli/internal/protect"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
 	"github.com/spf13/cobra"
 )
 
+var deleteYes bool
+
 func newDeleteCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "delete [alias_id]",
-		Short: "Delete an alias",
-		Long:  deleteDescription,
-		Args:  cobra.ExactArgs(1),
+		Use:               "delete [alias_id]",
+		Short:             "Delete an alias",
+		Long:              deleteDescription,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.AliasIDs),
 		Run: func(cmd *cobra.Command, args []string) {
 			runDelete(outputFormat, args)
 		},
 	}
 
 	cmd.Flags().BoolVar(&compact, "compact", false, "Compact output")
+	cmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Do not ask for confirmation")
 
 	return cmd
 }
@@ -51,6 +60,26 @@ func runDelete(outputFormat *string, args []string) {
 		log.Fatal(err)
 	}
 
+	rules, err := protect.New(cfg.Protect)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	target, err := client.GetAlias(aliasID)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := rules.Check(*target); err != nil {
+		log.Fatal(err)
+	}
+
+	if !deleteYes && prompt.IsTerminal() {
+		printAliasSummary(target)
+	}
+	if err := prompt.ConfirmAction(fmt.Sprintf("Delete %s?", target.Email), deleteYes); err != nil {
+		log.Fatal(err)
+	}
+
 	alias, err := client.DeleteAlias(aliasID)
 	if err != nil {
 		log.Fatal(err)
@@ -69,7 +98,44 @@ func runDelete(outputFormat *string, args []string) {
 	}
 }
 
+// skipProtected drops the aliases matched by a protection rule, reporting them on stderr
+func skipProtected(rules *protect.Rules, aliases []simplelogin.Alias) []simplelogin.Alias {
+	var allowed []simplelogin.Alias
+	for _, alias := range aliases {
+		if err := rules.Check(alias); err != nil {
+			fmt.Fprintf(os.Stderr, "Skipped: %v\n", err)
+			continue
+		}
+		allowed = append(allowed, alias)
+	}
+	return allowed
+}
+
+// printAliasSummary shows what deleting an alias destroys
+func printAliasSummary(alias *simplelogin.Alias) {
+	mailboxes := make([]string, len(alias.Mailboxes))
+	for i, mailbox := range alias.Mailboxes {
+		mailboxes[i] = mailbox.Email
+	}
+
+	fmt.Fprintf(os.Stderr, "Alias:     %s (ID %d)\n", alias.Email, alias.ID)
+	fmt.Fprintf(os.Stderr, "Mailboxes: %s\n", strings.Join(mailboxes, ", "))
+	fmt.Fprintf(os.Stderr, "Emails:    %d forwarded, %d replied, %d blocked\n", alias.NbForward, alias.NbReply, alias.NbBlock)
+	fmt.Fprintln(os.Stderr, "The alias and its contacts are deleted, emails sent to it will bounce.")
+}
+
 const deleteDescription = `
 Delete an alias
 
+The alias is shown and a confirmation asked first; pass --yes in scripts.
+Aliases matched by the "protect" rules of the configuration are never
+deleted:
+
+    "protect": {
+        "pinned": true,
+        "patterns": ["^billing@", "@bank\\.example\\.com$"]
+    }
+
+Pinned aliases are protected when "pinned" is set, patterns are regular
+expressions matched against the alias email, ignoring case.
 `
@@ -0,0 +1,187 @@
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execPrefix        string
+	execSuffix        string
+	execRandom        bool
+	execMode          string
+	execHostname      string
+	execNote          string
+	execMailboxes     []string
+	execKeepOnFailure bool
+)
+
+func newExecCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec [flags] -- [command] [args...]",
+		Short: "Run a command with an ephemeral alias",
+		Long:  execDescription,
+		Args:  cobra.MinimumNArgs(1),
+		Run:   runExec,
+	}
+
+	flags := cmd.Flags()
+	flags.SetInterspersed(false)
+
+	flags.StringVar(&execPrefix, "prefix", "", "Create the custom alias <prefix><suffix>")
+	flags.StringVar(&execSuffix, "suffix", "", "Suffix of the custom alias, e.g. @example.com (requires --prefix)")
+	flags.BoolVar(&execRandom, "random", false, "Create a random alias (default)")
+	flags.StringVarP(&execMode, "mode", "m", "", "Random alias mode (uuid, word)")
+	flags.StringVar(&execHostname, "hostname", "", "Website the alias is used on")
+	flags.StringVar(&execNote, "note", "Ephemeral alias created by simplelogin-cli alias exec", "Alias note")
+	flags.StringSliceVar(&execMailboxes, "mailbox", []string{}, "Mailbox email or ID for a custom alias (repeatable)")
+	flags.BoolVar(&execKeepOnFailure, "keep-on-failure", false, "Keep the alias when the command fails")
+
+	cmd.RegisterFlagCompletionFunc("mailbox", completion.List(completion.MailboxEmails))
+	cmd.RegisterFlagCompletionFunc("suffix", completion.DomainSuffixes)
+
+	return cmd
+}
+
+func runExec(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	if execRandom && execPrefix != "" {
+		log.Fatal("--random and --prefix are mutually exclusive")
+	}
+	if (execPrefix == "") != (execSuffix == "") {
+		log.Fatal("--prefix and --suffix must be used together")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	alias, err := createEphemeralAlias(client)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Created alias %s (ID %d)\n", alias.Email, alias.ID)
+
+	exitCode, runErr := runChild(args, alias)
+	if runErr != nil {
+		fmt.Fprintf(os.Stderr, "Failed to run command: %v\n", runErr)
+	}
+
+	if exitCode != 0 && execKeepOnFailure {
+		fmt.Fprintf(os.Stderr, "Command failed, keeping alias %s (ID %d)\n", alias.Email, alias.ID)
+	} else if _, err := client.DeleteAlias(alias.ID); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to delete alias %s (ID %d): %v\n", alias.Email, alias.ID, err)
+		if exitCode == 0 {
+			exitCode = 1
+		}
+	} else {
+		fmt.Fprintf(os.Stderr, "Deleted alias %s\n", alias.Email)
+	}
+
+	os.Exit(exitCode)
+}
+
+func createEphemeralAlias(client *simplelogin.Client) (*simplelogin.Alias, error) {
+	if execPrefix == "" {
+		return client.CreateRandomAlias(execHostname, execMode, execNote)
+	}
+
+	mailboxIDs, err := resolveMailboxIDs(client, execMailboxes)
+	if err != nil {
+		return nil, err
+	}
+	if len(mailboxIDs) == 0 {
+		if mailboxIDs, err = defaultMailboxIDs(client); err != nil {
+			return nil, err
+		}
+	}
+
+	input := simplelogin.AliasCreateCustomOptions{
+		MailboxIDs: mailboxIDs,
+		Note:       execNote,
+	}
+
+	return createCustomAliasFromEmail(client, execPrefix+execSuffix, execHostname, input)
+}
+
+// runChild runs the command with the alias exported in its environment,
+// forwarding signals to it, and returns its exit code
+func runChild(args []string, alias *simplelogin.Alias) (int, error) {
+	child := exec.Command(args[0], args[1:]...)
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+	child.Env = append(os.Environ(),
+		"SIMPLELOGIN_ALIAS="+alias.Email,
+		"SIMPLELOGIN_ALIAS_ID="+strconv.Itoa(alias.ID),
+	)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, syscall.SIGQUIT)
+	defer signal.Stop(signals)
+
+	if err := child.Start(); err != nil {
+		return 127, err
+	}
+
+	go func() {
+		for sig := range signals {
+			child.Process.Signal(sig)
+		}
+	}()
+
+	err := child.Wait()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal()), nil
+		}
+		return exitErr.ExitCode(), nil
+	}
+	if err != nil {
+		return 1, err
+	}
+
+	return 0, nil
+}
+
+const execDescription = `
+Run a command with an ephemeral alias
+
+An alias is created, exported to the command as SIMPLELOGIN_ALIAS and
+SIMPLELOGIN_ALIAS_ID, then deleted when the command exits, whatever its exit
+status. Signals received are forwarded to the command. The exit status of
+the command is returned.
+
+Examples:
+
+    simplelogin-cli alias exec -- ./run-tests.sh
+    simplelogin-cli alias exec --prefix e2e-$RUN_ID --suffix @example.com -- make e2e
+
+`
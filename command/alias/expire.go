@@ -0,0 +1,211 @@
+package alias
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/expiry"
+	"github.com/juli3nk/simplelogin-cli/internal/journal"
+	"github.com/juli3nk/simplelogin-cli/internal/protect"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+// Actions applied to expired aliases
+const (
+	expireActionDisable = "disable"
+	expireActionDelete  = "delete"
+)
+
+var (
+	expireAction string
+	expireDryRun bool
+)
+
+// ExpireResult represents what was done with an expired alias
+type ExpireResult struct {
+	ID      int       `json:"id"`
+	Email   string    `json:"email"`
+	Expires time.Time `json:"expires"`
+	Action  string    `json:"action"`
+	Error   string    `json:"error,omitempty"`
+}
+
+func newExpireCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "expire",
+		Short: "Disable or delete expired aliases",
+		Long:  expireDescription,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runExpire(outputFormat)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+	flags.BoolVar(&noHeaders, "no-headers", false, "Hide table headers")
+
+	flags.StringVar(&expireAction, "action", expireActionDisable, "What to do with expired aliases: disable or delete")
+	flags.BoolVar(&expireDryRun, "dry-run", false, "Only report the expired aliases")
+
+	return cmd
+}
+
+func runExpire(outputFormat *string) {
+	defer utils.RecoverFunc()
+
+	if expireAction != expireActionDisable && expireAction != expireActionDelete {
+		log.Fatalf("invalid action %q, expected %s or %s", expireAction, expireActionDisable, expireActionDelete)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	rules, err := protect.New(cfg.Protect)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	aliases, err := client.GetAllAliases(simplelogin.AliasListOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	now := time.Now()
+	results := []ExpireResult{}
+	failed := 0
+
+	for _, alias := range aliases {
+		expires, ok, err := expiry.Get(alias.Note)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: %s: %v\n", alias.Email, err)
+			continue
+		}
+		if !ok || expires.After(now) {
+			continue
+		}
+		// Disabled aliases are already expired
+		if expireAction == expireActionDisable && !alias.Enabled {
+			continue
+		}
+
+		result := ExpireResult{ID: alias.ID, Email: alias.Email, Expires: expires, Action: expireAction}
+		if expireAction == expireActionDelete && rules.Check(alias) != nil {
+			result.Action = "protected"
+		} else if expireDryRun {
+			result.Action = "would " + expireAction
+		} else if err := expireAlias(client, alias); err != nil {
+			result.Error = err.Error()
+			failed++
+		}
+
+		results = append(results, result)
+	}
+
+	switch *outputFormat {
+	case "json":
+		if err := display.DisplayData(results, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default: // table
+		if len(results) == 0 {
+			fmt.Println("No expired aliases.")
+			return
+		}
+
+		tableOpts := display.DefaultTableOptions()
+		if noHeaders {
+			tableOpts.NoHeaders = true
+		}
+		if compact {
+			tableOpts = display.CompactTableOptions()
+		}
+
+		table := display.NewTable(tableOpts)
+		table.SetHeader([]string{"ID", "Email", "Expires", "Action", "Error"})
+
+		for _, result := range results {
+			table.Append([]string{
+				display.FormatID(result.ID),
+				display.FormatEmail(result.Email, 35),
+				result.Expires.Local().Format(time.DateTime),
+				result.Action,
+				result.Error,
+			})
+		}
+
+		table.Render()
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func expireAlias(client *simplelogin.Client, alias simplelogin.Alias) error {
+	if expireAction == expireActionDelete {
+		_, err := client.DeleteAlias(alias.ID)
+		return err
+	}
+
+	if _, err := client.ToggleAlias(alias.ID); err != nil {
+		return err
+	}
+
+	journal.Warn(journal.Entry{
+		Action:  journal.ActionAliasToggle,
+		AliasID: alias.ID,
+		Subject: alias.Email,
+		Before:  journal.State{Enabled: &alias.Enabled},
+	})
+	return nil
+}
+
+// withExpiry records the expiry given to --expires in the alias note
+func withExpiry(note, expires string) (string, error) {
+	if expires == "" {
+		return note, nil
+	}
+
+	t, err := expiry.Parse(expires, time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	return expiry.Set(note, t), nil
+}
+
+const expireDescription = `
+Disable or delete expired aliases
+
+Aliases created with --expires carry an "#expires: <date>" line in their note.
+This command finds the aliases past their date and disables them (default)
+or deletes them with --action delete, then reports what it did. It exits
+with a non-zero status when an alias could not be expired.
+
+It is meant to run periodically, e.g. from cron:
+
+    0 * * * * simplelogin-cli alias expire --action disable
+
+`
@@ -0,0 +1,116 @@
+package alias
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/site"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	forMode     string
+	forNote     string
+	forNoCreate bool
+)
+
+func newForCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "for [url]",
+		Short: "Get or create the alias for a website",
+		Long:  forDescription,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runFor(outputFormat, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+
+	flags.StringVarP(&forMode, "mode", "m", "", "Random alias mode (uuid, word); defaults to the account setting")
+	flags.StringVar(&forNote, "note", "", "Note for a newly created alias")
+	flags.BoolVar(&forNoCreate, "no-create", false, "Fail instead of creating an alias when none exists")
+
+	return cmd
+}
+
+func runFor(outputFormat *string, args []string) {
+	defer utils.RecoverFunc()
+
+	domain, err := site.Normalize(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	aliases, err := site.Find(client, domain)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var alias *simplelogin.Alias
+	created := false
+	switch {
+	case len(aliases) > 0:
+		alias = &aliases[0]
+		if len(aliases) > 1 {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: %d aliases are tied to %s, using %s\n", len(aliases), domain, alias.Email)
+		}
+		if !alias.Enabled {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: alias %s is disabled\n", alias.Email)
+		}
+	case forNoCreate:
+		log.Fatalf("No alias found for %s", domain)
+	default:
+		alias, err = site.Create(client, domain, forMode, forNote)
+		if err != nil {
+			log.Fatal(err)
+		}
+		created = true
+	}
+
+	switch *outputFormat {
+	case "json":
+		if err := display.DisplayData(alias, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		if created {
+			fmt.Fprintf(os.Stderr, "Created alias for %s\n", domain)
+		}
+		fmt.Println(alias.Email)
+	}
+}
+
+const forDescription = `
+Get or create the alias for a website
+
+The URL is reduced to its registrable domain (https://shop.example.co.uk/login
+becomes example.co.uk) and aliases whose note contains a "#site: <domain>"
+line are looked up. When none exists, a random alias is created with the
+account's alias generator and tagged with that line.
+
+`
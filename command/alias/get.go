@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
@@ -14,10 +15,11 @@ import (
 
 func newGetCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "get [name]",
-		Short: "Get an alias",
-		Long:  getDescription,
-		Args:  cobra.ExactArgs(1),
+		Use:               "get [name]",
+		Short:             "Get an alias",
+		Long:              getDescription,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.AliasIDs),
 		Run: func(cmd *cobra.Command, args []string) {
 			runGet(outputFormat, args)
 		},
@@ -0,0 +1,284 @@
+package alias
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/importer"
+	"github.com/juli3nk/simplelogin-cli/internal/notemeta"
+	"github.com/juli3nk/simplelogin-cli/internal/tags"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+// importedFromNoteKey is the note metadata key recording the original address of an imported alias
+const importedFromNoteKey = "imported-from"
+
+// Import statuses
+const (
+	importStatusCreated = "created"
+	importStatusExists  = "exists"
+	importStatusPlanned = "planned"
+	importStatusFailed  = "failed"
+)
+
+var (
+	importFrom      string
+	importSuffix    string
+	importHostname  string
+	importMailboxes []string
+	importTags      []string
+	importRate      int
+	importDryRun    bool
+)
+
+// ImportResult maps an imported address to the alias created for it
+type ImportResult struct {
+	Old    string `json:"old"`
+	New    string `json:"new"`
+	ID     int    `json:"id,omitempty"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+func newImportCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import [file]",
+		Short: "Import aliases from another provider's export",
+		Long:  importDescription,
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runImport(outputFormat, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+	flags.BoolVar(&noHeaders, "no-headers", false, "Hide table headers")
+
+	flags.StringVar(&importFrom, "from", "", "Export format: "+strings.Join(importer.Formats, ", "))
+	flags.StringVarP(&importSuffix, "suffix", "s", "", "Suffix of the new aliases, e.g. @example.com (default: keep the original address)")
+	flags.StringVar(&importHostname, "hostname", "", "Website the aliases are used on")
+	flags.StringSliceVar(&importMailboxes, "mailbox", []string{}, "Mailbox email or ID (repeatable, default: the default mailbox)")
+	flags.StringSliceVar(&importTags, "tag", []string{}, "Tag added to every imported alias (repeatable)")
+	flags.IntVar(&importRate, "rate", 60, "Maximum API requests per minute (0 for no limit)")
+	flags.BoolVar(&importDryRun, "dry-run", false, "Only print the mapping, without creating aliases")
+
+	cmd.MarkFlagRequired("from")
+
+	cmd.RegisterFlagCompletionFunc("mailbox", completion.List(completion.MailboxEmails))
+	cmd.RegisterFlagCompletionFunc("suffix", completion.DomainSuffixes)
+
+	return cmd
+}
+
+func runImport(outputFormat *string, args []string) {
+	defer utils.RecoverFunc()
+
+	var r io.Reader = os.Stdin
+	if args[0] != "-" {
+		f, err := os.Open(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		r = f
+	}
+
+	entries, err := importer.Parse(importFrom, r)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tagList, err := normalizeTags(importTags)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	aliases, err := client.GetAllAliases(simplelogin.AliasListOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	existing := make(map[string]int, len(aliases))
+	for _, alias := range aliases {
+		existing[strings.ToLower(alias.Email)] = alias.ID
+	}
+
+	input := simplelogin.AliasCreateCustomOptions{}
+	var resolver *simplelogin.SuffixResolver
+	if !importDryRun {
+		if input.MailboxIDs, err = resolveMailboxIDs(client, importMailboxes); err != nil {
+			log.Fatal(err)
+		}
+		if len(input.MailboxIDs) == 0 {
+			if input.MailboxIDs, err = defaultMailboxIDs(client); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		resolver = simplelogin.NewSuffixResolver(client, importHostname, 0)
+		if importSuffix != "" {
+			if err := resolveBatchSuffix(client, resolver, importSuffix); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	client.SetRateLimit(importRate, time.Minute)
+
+	results := []ImportResult{}
+	failed := 0
+
+	for _, entry := range entries {
+		result := ImportResult{Old: entry.Address, New: entry.Address}
+		if importSuffix != "" {
+			result.New = entry.LocalPart + strings.ToLower(importSuffix)
+		}
+
+		if id, ok := existing[result.New]; ok {
+			result.ID = id
+			result.Status = importStatusExists
+			results = append(results, result)
+			continue
+		}
+
+		if importDryRun {
+			result.Status = importStatusPlanned
+			results = append(results, result)
+			continue
+		}
+
+		input.Note = tags.Add(notemeta.Set(entry.Description, importedFromNoteKey, entry.Address), tagList...)
+
+		alias, err := importAlias(client, resolver, entry, result.New, input)
+		if err != nil {
+			result.Status = importStatusFailed
+			result.Error = err.Error()
+			failed++
+		} else {
+			result.New = alias.Email
+			result.ID = alias.ID
+			result.Status = importStatusCreated
+			existing[strings.ToLower(alias.Email)] = alias.ID
+
+			if !entry.Enabled {
+				if _, err := client.ToggleAlias(alias.ID); err != nil {
+					result.Error = fmt.Sprintf("created but not disabled: %v", err)
+					failed++
+				}
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	switch *outputFormat {
+	case "json":
+		if err := display.DisplayData(results, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default: // table
+		if len(results) == 0 {
+			fmt.Println("No aliases found in the export.")
+			return
+		}
+
+		tableOpts := display.DefaultTableOptions()
+		if noHeaders {
+			tableOpts.NoHeaders = true
+		}
+		if compact {
+			tableOpts = display.CompactTableOptions()
+		}
+
+		table := display.NewTable(tableOpts)
+		table.SetHeader([]string{"Old", "New", "ID", "Status", "Error"})
+
+		for _, result := range results {
+			id := ""
+			if result.ID != 0 {
+				id = display.FormatID(result.ID)
+			}
+
+			table.Append([]string{result.Old, result.New, id, result.Status, result.Error})
+		}
+
+		table.Render()
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// importAlias creates the alias for an entry, waiting and retrying when rate limited
+func importAlias(client *simplelogin.Client, resolver *simplelogin.SuffixResolver, entry importer.Entry, email string, input simplelogin.AliasCreateCustomOptions) (*simplelogin.Alias, error) {
+	for attempt := 0; ; attempt++ {
+		var alias *simplelogin.Alias
+		var err error
+		if importSuffix != "" {
+			alias, err = resolver.CreateWithSuffix(entry.LocalPart, importSuffix, input)
+		} else if _, _, err = resolveSuffix(client, resolver, email, false); err == nil {
+			alias, err = resolver.Create(email, input)
+		}
+
+		if wait, ok := retryAfter(err); ok && attempt < 3 {
+			time.Sleep(wait)
+			continue
+		}
+
+		return alias, err
+	}
+}
+
+const importDescription = `
+Import aliases from another provider's export
+
+Supported formats (--from):
+  addy             CSV export of addy.io
+  relay            Firefox Relay masks, JSON from the API or CSV
+  duckduckgo       plain list of Duck addresses, one per line,
+                   optionally followed by ",description"
+  simplelogin-csv  CSV export of SimpleLogin
+
+The local part of each address becomes the prefix of the new alias, followed
+by --suffix. Without --suffix the original address is kept, which works when
+its domain is available to the account (e.g. a custom domain moved over).
+Descriptions become the alias note, with an "#imported-from: <address>" line.
+Aliases that already exist are skipped, and disabled aliases stay disabled.
+
+A mapping report of old address to new address is printed. Use --dry-run to
+review it before creating anything.
+
+Example:
+
+    simplelogin-cli alias import --from addy --suffix @example.com aliases.csv
+
+`
@@ -17,6 +17,7 @@ var (
 	aliasListDisabled bool
 	aliasListEnabled  bool
 	aliasListQuery    string
+	aliasListTags     []string
 )
 
 func newListCommand(outputFormat *string) *cobra.Command {
@@ -39,6 +40,7 @@ func newListCommand(outputFormat *string) *cobra.Command {
 	flags.BoolVarP(&aliasListDisabled, "disabled", "d", false, "Disabled aliases")
 	flags.BoolVarP(&aliasListEnabled, "enabled", "e", false, "Enabled aliases")
 	flags.StringVarP(&aliasListQuery, "query", "q", "", "Query aliases")
+	flags.StringSliceVarP(&aliasListTags, "tag", "t", []string{}, "Only aliases with this tag (repeatable)")
 
 	return cmd
 }
@@ -73,10 +75,17 @@ func runList(outputFormat *string, args []string) {
 		log.Fatal(err)
 	}
 
-	aliases, err := client.GetAliases(opts, pageID)
+	// Tags live in the notes, which the API cannot filter on: search every page
+	var aliases []simplelogin.Alias
+	if len(aliasListTags) > 0 {
+		aliases, err = client.GetAllAliases(opts)
+	} else {
+		aliases, err = client.GetAliases(opts, pageID)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
+	aliases = filterTagged(aliases, aliasListTags)
 
 	switch *outputFormat {
 	case "json":
@@ -137,4 +146,7 @@ func runList(outputFormat *string, args []string) {
 const listDescription = `
 List aliases
 
+With --tag, every page is searched and all the aliases carrying the given
+tags are shown; page_id is ignored.
+
 `
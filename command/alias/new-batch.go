@@ -0,0 +1,237 @@
+package alias
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/batch"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/notemeta"
+	"github.com/juli3nk/simplelogin-cli/internal/tags"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	newBatchTemplate    string
+	newBatchCount       int
+	newBatchStart       int
+	newBatchSuffix      string
+	newBatchHostname    string
+	newBatchMailboxes   []string
+	newBatchVars        []string
+	newBatchNote        string
+	newBatchID          string
+	newBatchTags        []string
+	newBatchExpires     string
+	newBatchConcurrency int
+	newBatchRate        int
+	newBatchOut         string
+)
+
+func newCreateBatchCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "new-batch",
+		Short: "Create a batch of aliases from a template",
+		Long:  createBatchDescription,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runCreateBatch(outputFormat)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&newBatchTemplate, "template", "t", "", "Alias prefix template, e.g. 'qa-{run}-user{n:02}'")
+	flags.IntVarP(&newBatchCount, "count", "c", 1, "Number of aliases to create")
+	flags.IntVar(&newBatchStart, "start", 1, "First value of {n}")
+	flags.StringVarP(&newBatchSuffix, "suffix", "s", "", "Alias suffix, e.g. @example.com")
+	flags.StringVar(&newBatchHostname, "hostname", "", "Website the aliases are used on")
+	flags.StringSliceVar(&newBatchMailboxes, "mailbox", []string{}, "Mailbox email or ID (repeatable, default: the default mailbox)")
+	flags.StringArrayVar(&newBatchVars, "var", []string{}, "Template variable as key=value (repeatable)")
+	flags.StringVar(&newBatchNote, "note", "", "Alias note")
+	flags.StringVar(&newBatchID, "batch", "", "Batch marker recorded in the alias note (default: the expanded template)")
+	flags.StringSliceVar(&newBatchTags, "tag", []string{}, "Tag added to every alias (repeatable)")
+	flags.StringVar(&newBatchExpires, "expires", "", "Expire the aliases after a duration or on a date, e.g. 7d")
+	flags.IntVar(&newBatchConcurrency, "concurrency", 4, "Number of aliases created in parallel")
+	flags.IntVar(&newBatchRate, "rate", 60, "Maximum API requests per minute (0 for no limit)")
+	flags.StringVar(&newBatchOut, "out", "", "Write results to this file (.csv or .json) instead of stdout")
+
+	cmd.MarkFlagRequired("template")
+	cmd.MarkFlagRequired("suffix")
+
+	cmd.RegisterFlagCompletionFunc("mailbox", completion.List(completion.MailboxEmails))
+	cmd.RegisterFlagCompletionFunc("suffix", completion.DomainSuffixes)
+
+	return cmd
+}
+
+func runCreateBatch(outputFormat *string) {
+	defer utils.RecoverFunc()
+
+	if newBatchCount <= 0 {
+		log.Fatal("--count must be positive")
+	}
+
+	tmpl, err := batch.Parse(newBatchTemplate)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	vars, err := parseVars(newBatchVars)
+	if err != nil {
+		log.Fatal(err)
+	}
+	vars = batch.WithDefaults(vars, time.Now())
+
+	tagList, err := normalizeTags(newBatchTags)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	note, err := withExpiry(newBatchNote, newBatchExpires)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	prefixes := make([]string, newBatchCount)
+	for i := range prefixes {
+		if prefixes[i], err = tmpl.Expand(newBatchStart+i, vars); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	batchID := newBatchID
+	if batchID == "" {
+		if batchID, err = tmpl.Name(vars); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client.SetRateLimit(newBatchRate, time.Minute)
+
+	mailboxIDs, err := resolveMailboxIDs(client, newBatchMailboxes)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(mailboxIDs) == 0 {
+		if mailboxIDs, err = defaultMailboxIDs(client); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	resolver := simplelogin.NewSuffixResolver(client, newBatchHostname, 0)
+	if err := resolveBatchSuffix(client, resolver, newBatchSuffix); err != nil {
+		log.Fatal(err)
+	}
+
+	input := simplelogin.AliasCreateCustomOptions{
+		MailboxIDs: mailboxIDs,
+		Note:       tags.Add(notemeta.Set(note, batchNoteKey, batchID), tagList...),
+	}
+
+	jobs := make(chan int)
+	results := make([]BatchResult, len(prefixes))
+
+	var wg sync.WaitGroup
+	for w := 0; w < max(newBatchConcurrency, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = createBatchAlias(resolver, newBatchStart+i, prefixes[i], newBatchSuffix, input)
+			}
+		}()
+	}
+
+	for i := range prefixes {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].N < results[j].N })
+
+	if err := writeBatchResults(results, newBatchOut, *outputFormat); err != nil {
+		log.Fatal(err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != "" {
+			failed++
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Batch %s: %d created, %d failed\n", batchID, len(results)-failed, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// createBatchAlias creates one alias, waiting and retrying when rate limited
+func createBatchAlias(resolver *simplelogin.SuffixResolver, n int, prefix, suffix string, input simplelogin.AliasCreateCustomOptions) BatchResult {
+	result := BatchResult{N: n, Email: prefix + strings.ToLower(suffix)}
+
+	for attempt := 0; ; attempt++ {
+		alias, err := resolver.CreateWithSuffix(prefix, suffix, input)
+		if err == nil {
+			result.Email = alias.Email
+			result.ID = alias.ID
+			return result
+		}
+
+		if wait, ok := retryAfter(err); ok && attempt < 3 {
+			time.Sleep(wait)
+			continue
+		}
+
+		result.Error = err.Error()
+		return result
+	}
+}
+
+const createBatchDescription = `
+Create a batch of aliases from a template
+
+Placeholders in the template:
+  {n}       sequence number, from --start
+  {n:02}    sequence number zero padded to 2 digits
+  {run}     value of --var run=value, defaults to a short ID of the
+            creation time (seconds since the epoch in base 36)
+  {name}    value of --var name=value
+
+Every alias note gets a "#batch: <marker>" line, the marker being printed at
+the end, so the batch can be removed with
+'simplelogin-cli alias delete-batch --batch <marker>'. Requests are spread to
+stay under --rate requests per minute; rate limited requests are retried.
+
+Examples:
+
+    simplelogin-cli alias new-batch --template 'qa-{run}-user{n:02}' \
+      --count 50 --suffix @example.com --mailbox qa@example.com --out aliases.csv
+
+    simplelogin-cli alias new-batch --template 'qa-{run}-user{n:02}' --var run=run42 \
+      --count 10 --suffix @example.com
+
+`
@@ -3,10 +3,14 @@ package alias
 import (
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/prompt"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
 	"github.com/spf13/cobra"
 )
@@ -15,16 +19,20 @@ var (
 	createNewAliasPrefix  string
 	createNewSignedSuffix string
 	createNewMailboxIds   []int
+	createNewMailboxes    []string
+	createNewHostname     string
 	createNewNote         string
 	createNewName         string
+	createNewInteractive  bool
+	createNewExpires      string
 )
 
 func newCreateNewCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "new [hostname]",
+		Use:   "new [prefix@domain | hostname]",
 		Short: "Create new alias",
 		Long:  createNewDescription,
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			runCreateNew(outputFormat, args)
 		},
@@ -36,8 +44,15 @@ func newCreateNewCommand(outputFormat *string) *cobra.Command {
 	flags.StringVarP(&createNewAliasPrefix, "alias-prefix", "a", "", "The first part of the alias that user can choose")
 	flags.StringVarP(&createNewSignedSuffix, "signed-suffix", "s", "", "Should be one of the suffixes returned in the GET /api/v5/alias/options endpoint")
 	flags.IntSliceVarP(&createNewMailboxIds, "mailbox-ids", "m", []int{}, "List of mailbox_id that 'owns' this alias")
+	flags.StringSliceVar(&createNewMailboxes, "mailbox", []string{}, "Mailbox email or ID that 'owns' this alias (repeatable)")
+	flags.StringVar(&createNewHostname, "hostname", "", "Website the alias is used on, when creating prefix@domain")
 	flags.StringVar(&createNewNote, "note", "", "Alias note")
 	flags.StringVar(&createNewName, "name", "", "Alias name")
+	flags.BoolVarP(&createNewInteractive, "interactive", "i", false, "Choose prefix, suffix and mailboxes interactively")
+	flags.StringVar(&createNewExpires, "expires", "", "Expire the alias after a duration or on a date, e.g. 30d or 2026-12-31")
+
+	cmd.RegisterFlagCompletionFunc("mailbox-ids", completion.List(completion.MailboxIDs))
+	cmd.RegisterFlagCompletionFunc("mailbox", completion.List(completion.MailboxEmails))
 
 	return cmd
 }
@@ -45,6 +60,15 @@ func newCreateNewCommand(outputFormat *string) *cobra.Command {
 func runCreateNew(outputFormat *string, args []string) {
 	defer utils.RecoverFunc()
 
+	interactive := createNewInteractive
+	if interactive && !prompt.IsTerminal() {
+		fmt.Fprintln(os.Stderr, "⚠️  Warning: stdin is not a terminal, using flags instead of the interactive wizard.")
+		interactive = false
+	}
+	if !interactive && len(args) == 0 {
+		log.Fatal("An alias address or hostname is required")
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatal(err)
@@ -60,12 +84,50 @@ func runCreateNew(outputFormat *string, args []string) {
 		log.Fatal(err)
 	}
 
-	hostname := args[0]
+	var alias *simplelogin.Alias
+	if interactive {
+		hostname := createNewHostname
+		if len(args) > 0 && !strings.Contains(args[0], "@") {
+			hostname = args[0]
+		}
+
+		input, suffix, err := promptCustomAlias(client, hostname)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if input.Note, err = withExpiry(input.Note, createNewExpires); err != nil {
+			log.Fatal(err)
+		}
+
+		// The selected option carries its own signed suffix: looking it up
+		// again by address could pick another option with the same suffix
+		resolver := simplelogin.NewSuffixResolver(client, hostname, 0)
+		alias, err = resolver.CreateWithOption(input.AliasPrefix, *suffix, input)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		printAlias(outputFormat, alias)
+		return
+	}
+
+	mailboxIDs, err := resolveMailboxIDs(client, createNewMailboxes)
+	if err != nil {
+		log.Fatal(err)
+	}
+	mailboxIDs = append(mailboxIDs, createNewMailboxIds...)
+
+	if len(mailboxIDs) == 0 {
+		mailboxIDs, err = defaultMailboxIDs(client)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
 
 	input := simplelogin.AliasCreateCustomOptions{
 		AliasPrefix:  createNewAliasPrefix,
 		SignedSuffix: createNewSignedSuffix,
-		MailboxIDs:   createNewMailboxIds,
+		MailboxIDs:   mailboxIDs,
 	}
 	if createNewNote != "" {
 		input.Note = createNewNote
@@ -73,12 +135,26 @@ func runCreateNew(outputFormat *string, args []string) {
 	if createNewName != "" {
 		input.Name = createNewName
 	}
+	if input.Note, err = withExpiry(input.Note, createNewExpires); err != nil {
+		log.Fatal(err)
+	}
 
-	alias, err := client.CreateCustomAlias(hostname, input)
+	if strings.Contains(args[0], "@") {
+		alias, err = createCustomAliasFromEmail(client, args[0], createNewHostname, input)
+	} else {
+		if createNewSignedSuffix == "" {
+			log.Fatal("--signed-suffix is required when no prefix@domain address is given")
+		}
+		alias, err = client.CreateCustomAlias(args[0], input)
+	}
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	printAlias(outputFormat, alias)
+}
+
+func printAlias(outputFormat *string, alias *simplelogin.Alias) {
 	switch *outputFormat {
 	case "json":
 		if err := display.DisplayData(alias, &display.DisplayOptions{
@@ -110,4 +186,18 @@ func runCreateNew(outputFormat *string, args []string) {
 const createNewDescription = `
 Create new alias
 
+Give the full address to create, e.g. shop-2026@example.com: the matching
+signed suffix is looked up from the alias options. Mailboxes can be given by
+email or ID with --mailbox; the default mailbox is used otherwise.
+
+With --interactive, the prefix suggestion, suffixes and verified mailboxes
+are offered as choices and a summary is shown before the alias is created.
+When stdin is not a terminal, the flags are used instead.
+
+With --expires, the expiry is recorded in the alias note and
+'simplelogin-cli alias expire' disables or deletes the alias once it is past.
+
+The legacy form takes a hostname and requires --alias-prefix and
+--signed-suffix from 'simplelogin-cli alias options'.
+
 `
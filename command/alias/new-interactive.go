@@ -0,0 +1,117 @@
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/juli3nk/simplelogin-cli/internal/prompt"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+var errAborted = errors.New("aborted")
+
+// promptCustomAlias walks the user through the alias options and returns the
+// creation input, with the signed suffix of the selected option, and that option
+func promptCustomAlias(client *simplelogin.Client, hostname string) (simplelogin.AliasCreateCustomOptions, *simplelogin.AliasOptionsSuffix, error) {
+	var input simplelogin.AliasCreateCustomOptions
+
+	options, err := client.GetAliasOptions(hostname)
+	if err != nil {
+		return input, nil, err
+	}
+	if !options.CanCreate {
+		return input, nil, errors.New("this account cannot create more aliases")
+	}
+
+	prefix, err := prompt.LineDefault("Alias prefix", options.PrefixSuggestion)
+	if err != nil {
+		return input, nil, err
+	}
+	if prefix == "" {
+		return input, nil, errors.New("alias prefix is required")
+	}
+
+	suffixLabels := make([]string, len(options.Suffixes))
+	for i, suffix := range options.Suffixes {
+		suffixLabels[i] = suffix.Suffix
+		if suffix.IsCustom {
+			suffixLabels[i] += " [custom]"
+		}
+		if suffix.IsPremium {
+			suffixLabels[i] += " [premium]"
+		}
+	}
+
+	suffixIdx, err := prompt.Select("Suffix:", suffixLabels, 0)
+	if err != nil {
+		return input, nil, err
+	}
+	suffix := options.Suffixes[suffixIdx]
+
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		return input, nil, err
+	}
+
+	var verified []simplelogin.Mailbox
+	var mailboxLabels []string
+	var defaults []int
+	for _, mailbox := range mailboxes {
+		if !mailbox.Verified {
+			continue
+		}
+		label := mailbox.Email
+		if mailbox.Default {
+			label += " [default]"
+			defaults = append(defaults, len(verified))
+		}
+		verified = append(verified, mailbox)
+		mailboxLabels = append(mailboxLabels, label)
+	}
+
+	mailboxIdxs, err := prompt.MultiSelect("Mailboxes:", mailboxLabels, defaults)
+	if err != nil {
+		return input, nil, err
+	}
+	if len(mailboxIdxs) == 0 {
+		return input, nil, errors.New("at least one mailbox is required")
+	}
+
+	mailboxEmails := make([]string, len(mailboxIdxs))
+	for i, idx := range mailboxIdxs {
+		input.MailboxIDs = append(input.MailboxIDs, verified[idx].ID)
+		mailboxEmails[i] = verified[idx].Email
+	}
+
+	if input.Note, err = prompt.LineDefault("Note", ""); err != nil {
+		return input, nil, err
+	}
+	if input.Name, err = prompt.LineDefault("Display name", ""); err != nil {
+		return input, nil, err
+	}
+
+	email := prefix + suffix.Suffix
+
+	fmt.Printf("\nAlias:     %s\n", email)
+	fmt.Printf("Mailboxes: %s\n", strings.Join(mailboxEmails, ", "))
+	if input.Note != "" {
+		fmt.Printf("Note:      %s\n", input.Note)
+	}
+	if input.Name != "" {
+		fmt.Printf("Name:      %s\n", input.Name)
+	}
+
+	ok, err := prompt.Confirm("Create this alias?", true)
+	if err != nil {
+		return input, nil, err
+	}
+	if !ok {
+		return input, nil, errAborted
+	}
+
+	input.AliasPrefix = prefix
+	input.SignedSuffix = suffix.SignedSuffix
+
+	return input, &suffix, nil
+}
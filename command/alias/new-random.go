@@ -12,8 +12,9 @@ import (
 )
 
 var (
-	createRandomMode string
-	createRandomNote string
+	createRandomMode    string
+	createRandomNote    string
+	createRandomExpires string
 )
 
 func newCreateRandomCommand(outputFormat *string) *cobra.Command {
@@ -32,6 +33,7 @@ func newCreateRandomCommand(outputFormat *string) *cobra.Command {
 
 	flags.StringVarP(&createRandomMode, "mode", "m", "", "The mode of the alias")
 	flags.StringVar(&createRandomNote, "note", "", "Alias note")
+	flags.StringVar(&createRandomExpires, "expires", "", "Expire the alias after a duration or on a date, e.g. 30d or 2026-12-31")
 
 	return cmd
 }
@@ -56,7 +58,12 @@ func runCreateRandom(outputFormat *string, args []string) {
 
 	hostname := args[0]
 
-	alias, err := client.CreateRandomAlias(hostname, createRandomMode, createRandomNote)
+	note, err := withExpiry(createRandomNote, createRandomExpires)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	alias, err := client.CreateRandomAlias(hostname, createRandomMode, note)
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -0,0 +1,263 @@
+package alias
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/passwords"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+// Reconcile finding kinds
+const (
+	findingDisabled = "disabled"
+	findingDeleted  = "deleted"
+	findingMailbox  = "mailbox"
+	findingOrphaned = "orphaned"
+)
+
+var (
+	reconcilePasswords string
+	reconcileFormat    string
+)
+
+// Finding is a mismatch between the password manager and the aliases
+type Finding struct {
+	Kind   string `json:"kind"`
+	Site   string `json:"site,omitempty"`
+	Login  string `json:"login,omitempty"`
+	Alias  string `json:"alias,omitempty"`
+	Detail string `json:"detail"`
+}
+
+func newReconcileCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Cross-reference aliases with a password manager export",
+		Long:  reconcileDescription,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runReconcile(outputFormat)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+	flags.BoolVar(&noHeaders, "no-headers", false, "Hide table headers")
+
+	flags.StringVar(&reconcilePasswords, "passwords", "", "Password manager export (Bitwarden JSON/CSV, KeePass CSV, 1Password CSV)")
+	flags.StringVar(&reconcileFormat, "format", "", "Export format: "+strings.Join(passwords.Formats, ", ")+" (default: detected)")
+
+	cmd.MarkFlagRequired("passwords")
+
+	return cmd
+}
+
+func runReconcile(outputFormat *string) {
+	defer utils.RecoverFunc()
+
+	data, err := os.ReadFile(reconcilePasswords)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	format := reconcileFormat
+	if format == "" {
+		if format, err = passwords.DetectFormat(reconcilePasswords, data); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	logins, err := passwords.Parse(format, data)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	aliases, err := client.GetAllAliases(simplelogin.AliasListOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	domains, err := aliasDomains(client, aliases)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	findings := reconcile(logins, aliases, mailboxes, domains)
+
+	switch *outputFormat {
+	case "json":
+		if findings == nil {
+			findings = []Finding{}
+		}
+		if err := display.DisplayData(findings, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default: // table
+		if len(findings) == 0 {
+			fmt.Println("Aliases and logins match.")
+			return
+		}
+
+		tableOpts := display.DefaultTableOptions()
+		if noHeaders {
+			tableOpts.NoHeaders = true
+		}
+		if compact {
+			tableOpts = display.CompactTableOptions()
+		}
+
+		table := display.NewTable(tableOpts)
+		table.SetHeader([]string{"Kind", "Site", "Login", "Alias", "Detail"})
+
+		for _, finding := range findings {
+			table.Append([]string{finding.Kind, finding.Site, finding.Login, finding.Alias, finding.Detail})
+		}
+
+		table.Render()
+		fmt.Printf("\nTotal: %d findings for %d logins and %d aliases\n", len(findings), len(logins), len(aliases))
+	}
+}
+
+// aliasDomains returns the domains aliases can be created on: custom
+// domains, SimpleLogin suffix domains and the domains of existing aliases
+func aliasDomains(client *simplelogin.Client, aliases []simplelogin.Alias) (map[string]bool, error) {
+	domains := make(map[string]bool)
+
+	for _, alias := range aliases {
+		domains[emailDomain(alias.Email)] = true
+	}
+
+	customDomains, err := client.GetDomains()
+	if err != nil {
+		return nil, err
+	}
+	for _, domain := range customDomains {
+		domains[strings.ToLower(domain.DomainName)] = true
+	}
+
+	options, err := client.GetAliasOptions("")
+	if err != nil {
+		return nil, err
+	}
+	for _, suffix := range options.Suffixes {
+		domains[emailDomain(suffix.Suffix)] = true
+	}
+
+	return domains, nil
+}
+
+// reconcile matches login usernames against aliases and mailboxes
+func reconcile(logins []passwords.Login, aliases []simplelogin.Alias, mailboxes []simplelogin.Mailbox, domains map[string]bool) []Finding {
+	byEmail := make(map[string]simplelogin.Alias, len(aliases))
+	for _, alias := range aliases {
+		byEmail[strings.ToLower(alias.Email)] = alias
+	}
+
+	isMailbox := make(map[string]bool, len(mailboxes))
+	for _, mailbox := range mailboxes {
+		isMailbox[strings.ToLower(mailbox.Email)] = true
+	}
+
+	var findings []Finding
+	used := make(map[string]bool)
+
+	for _, login := range logins {
+		username := strings.ToLower(login.Username)
+		if !strings.Contains(username, "@") {
+			continue
+		}
+
+		site := loginSite(login)
+
+		if alias, ok := byEmail[username]; ok {
+			used[username] = true
+			if !alias.Enabled {
+				findings = append(findings, Finding{Kind: findingDisabled, Site: site, Login: login.Username, Alias: alias.Email, Detail: "login uses a disabled alias"})
+			}
+			continue
+		}
+
+		switch {
+		case isMailbox[username]:
+			findings = append(findings, Finding{Kind: findingMailbox, Site: site, Login: login.Username, Detail: "login uses a real mailbox address"})
+		case domains[emailDomain(username)]:
+			findings = append(findings, Finding{Kind: findingDeleted, Site: site, Login: login.Username, Detail: "login uses an alias that no longer exists"})
+		}
+	}
+
+	for _, alias := range aliases {
+		if alias.Enabled && !used[strings.ToLower(alias.Email)] {
+			findings = append(findings, Finding{Kind: findingOrphaned, Alias: alias.Email, Detail: "no login uses this alias"})
+		}
+	}
+
+	order := map[string]int{findingDisabled: 0, findingDeleted: 1, findingMailbox: 2, findingOrphaned: 3}
+	sort.SliceStable(findings, func(i, j int) bool { return order[findings[i].Kind] < order[findings[j].Kind] })
+
+	return findings
+}
+
+func loginSite(login passwords.Login) string {
+	if u, err := url.Parse(login.URL); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	if login.URL != "" {
+		return login.URL
+	}
+	return login.Name
+}
+
+func emailDomain(email string) string {
+	_, domain, _ := strings.Cut(strings.ToLower(email), "@")
+	return domain
+}
+
+const reconcileDescription = `
+Cross-reference aliases with a password manager export
+
+Login usernames are matched against the aliases of the account to report:
+  disabled  logins using an alias that is now disabled
+  deleted   logins using an address on an alias domain that no longer exists
+  mailbox   logins using a real mailbox address instead of an alias
+  orphaned  enabled aliases no login uses
+
+Supported exports: Bitwarden (unencrypted JSON or CSV), KeePass/KeePassXC CSV
+and 1Password CSV. The export is only read locally, passwords are ignored.
+
+Example:
+
+    simplelogin-cli alias reconcile --passwords bitwarden.json
+
+`
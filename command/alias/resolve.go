@@ -0,0 +1,162 @@
+package alias
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+// resolveSuffix picks the suffix of a full alias address, refusing premium
+// suffixes on a free account
+func resolveSuffix(client *simplelogin.Client, resolver *simplelogin.SuffixResolver, email string, refresh bool) (string, *simplelogin.AliasOptionsSuffix, error) {
+	prefix, suffix, err := resolver.Resolve(email, refresh)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if err := checkPremiumSuffix(client, suffix); err != nil {
+		return "", nil, err
+	}
+
+	return prefix, suffix, nil
+}
+
+// checkPremiumSuffix refuses a premium suffix on a free account
+func checkPremiumSuffix(client *simplelogin.Client, suffix *simplelogin.AliasOptionsSuffix) error {
+	if !suffix.IsPremium {
+		return nil
+	}
+
+	userInfo, err := client.GetUserInfo()
+	if err != nil {
+		return err
+	}
+	if !userInfo.IsPremium {
+		return fmt.Errorf("suffix %s requires a premium account", suffix.Suffix)
+	}
+
+	return nil
+}
+
+// resolveBatchSuffix checks that the suffix shared by a batch of aliases is
+// available to the account
+func resolveBatchSuffix(client *simplelogin.Client, resolver *simplelogin.SuffixResolver, suffix string) error {
+	option, err := resolver.ResolveSuffix(suffix, false)
+	if err != nil {
+		return err
+	}
+
+	return checkPremiumSuffix(client, option)
+}
+
+// createCustomAliasFromEmail creates the custom alias prefix@domain, resolving
+// the signed suffix and refreshing it once if the server rejects its signature
+func createCustomAliasFromEmail(client *simplelogin.Client, email, hostname string, input simplelogin.AliasCreateCustomOptions) (*simplelogin.Alias, error) {
+	resolver := simplelogin.NewSuffixResolver(client, hostname, 0)
+	if _, _, err := resolveSuffix(client, resolver, email, false); err != nil {
+		return nil, err
+	}
+
+	return resolver.Create(email, input)
+}
+
+// resolveMailboxIDs turns mailbox IDs or emails into verified mailbox IDs
+func resolveMailboxIDs(client *simplelogin.Client, refs []string) ([]int, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(refs))
+	for _, ref := range refs {
+		mailbox := findMailbox(mailboxes, ref)
+		if mailbox == nil {
+			return nil, fmt.Errorf("mailbox %s not found", ref)
+		}
+		if !mailbox.Verified {
+			return nil, fmt.Errorf("mailbox %s is not verified", mailbox.Email)
+		}
+		ids = append(ids, mailbox.ID)
+	}
+
+	return ids, nil
+}
+
+// findMailbox finds a mailbox by ID or email
+func findMailbox(mailboxes []simplelogin.Mailbox, ref string) *simplelogin.Mailbox {
+	ref = strings.TrimSpace(ref)
+	id, idErr := strconv.Atoi(ref)
+
+	for i := range mailboxes {
+		if idErr == nil && mailboxes[i].ID == id {
+			return &mailboxes[i]
+		}
+		if strings.EqualFold(mailboxes[i].Email, ref) {
+			return &mailboxes[i]
+		}
+	}
+
+	return nil
+}
+
+// defaultMailboxIDs returns the ID of the default mailbox
+func defaultMailboxIDs(client *simplelogin.Client) ([]int, error) {
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mailbox := range mailboxes {
+		if mailbox.Default {
+			return []int{mailbox.ID}, nil
+		}
+	}
+
+	return nil, errors.New("no default mailbox found, use --mailbox")
+}
+
+// findAlias finds an alias by ID or email
+func findAlias(client *simplelogin.Client, ref string) (*simplelogin.Alias, error) {
+	ref = strings.TrimSpace(ref)
+
+	if id, err := strconv.Atoi(ref); err == nil {
+		return client.GetAlias(id)
+	}
+
+	aliases, err := client.GetAllAliases(simplelogin.AliasListOptions{Query: ref})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range aliases {
+		if strings.EqualFold(aliases[i].Email, ref) {
+			return &aliases[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("alias %s not found", ref)
+}
+
+// retryAfter reports whether err is a rate limit error and how long to wait
+func retryAfter(err error) (time.Duration, bool) {
+	var rateErr *simplelogin.RateLimitError
+	if errors.As(err, &rateErr) {
+		return time.Duration(max(rateErr.RetryAfter, 1)) * time.Second, true
+	}
+
+	var apiErr *simplelogin.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+		return time.Minute, true
+	}
+
+	return 0, false
+}
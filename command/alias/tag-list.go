@@ -0,0 +1,140 @@
+package alias
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/tags"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+// TagCount represents a tag and the number of aliases carrying it
+type TagCount struct {
+	Tag     string `json:"tag"`
+	Aliases int    `json:"aliases"`
+}
+
+func newTagListCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list [alias_id | email]",
+		Aliases:           []string{"ls"},
+		Short:             "List the tags of an alias, or all tags in use",
+		Long:              tagListDescription,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.AliasEmails),
+		Run: func(cmd *cobra.Command, args []string) {
+			runTagList(outputFormat, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+	flags.BoolVar(&noHeaders, "no-headers", false, "Hide table headers")
+
+	return cmd
+}
+
+func runTagList(outputFormat *string, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(args) == 1 {
+		alias, err := findAlias(client, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		aliasTags := tags.Get(alias.Note)
+		switch *outputFormat {
+		case "json":
+			if aliasTags == nil {
+				aliasTags = []string{}
+			}
+			if err := display.DisplayData(aliasTags, &display.DisplayOptions{
+				Format:  display.FormatJSON,
+				Compact: compact,
+			}); err != nil {
+				log.Fatal(err)
+			}
+		default:
+			fmt.Println(strings.Join(aliasTags, "\n"))
+		}
+		return
+	}
+
+	aliases, err := client.GetAllAliases(simplelogin.AliasListOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	counts := make(map[string]int)
+	for _, alias := range aliases {
+		for _, tag := range tags.Get(alias.Note) {
+			counts[tag]++
+		}
+	}
+
+	result := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		result = append(result, TagCount{Tag: tag, Aliases: count})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Tag < result[j].Tag })
+
+	switch *outputFormat {
+	case "json":
+		if err := display.DisplayData(result, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default: // table
+		if len(result) == 0 {
+			fmt.Println("No tags found.")
+			return
+		}
+
+		tableOpts := display.DefaultTableOptions()
+		if noHeaders {
+			tableOpts.NoHeaders = true
+		}
+		if compact {
+			tableOpts = display.CompactTableOptions()
+		}
+
+		table := display.NewTable(tableOpts)
+		table.SetHeader([]string{"Tag", "Aliases"})
+
+		for _, tc := range result {
+			table.Append([]string{tc.Tag, display.FormatID(tc.Aliases)})
+		}
+
+		table.Render()
+	}
+}
+
+const tagListDescription = `
+List the tags of an alias, or all tags in use with their number of aliases
+
+`
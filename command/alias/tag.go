@@ -0,0 +1,164 @@
+package alias
+
+import (
+	"log"
+	"slices"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/journal"
+	"github.com/juli3nk/simplelogin-cli/internal/tags"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+func newTagCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Manage alias tags",
+		Long:  tagDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+
+	cmd.AddCommand(
+		newTagAddCommand(),
+		newTagListCommand(outputFormat),
+		newTagRemoveCommand(),
+	)
+
+	return cmd
+}
+
+func newTagAddCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "add [alias_id | email] [tag...]",
+		Short:             "Add tags to an alias",
+		Long:              tagAddDescription,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completion.FirstArg(completion.AliasEmails),
+		Run: func(cmd *cobra.Command, args []string) {
+			runTagUpdate(args, tags.Add)
+		},
+	}
+
+	return cmd
+}
+
+func newTagRemoveCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove [alias_id | email] [tag...]",
+		Aliases:           []string{"rm"},
+		Short:             "Remove tags from an alias",
+		Long:              tagRemoveDescription,
+		Args:              cobra.MinimumNArgs(2),
+		ValidArgsFunction: completion.FirstArg(completion.AliasEmails),
+		Run: func(cmd *cobra.Command, args []string) {
+			runTagUpdate(args, tags.Remove)
+		},
+	}
+
+	return cmd
+}
+
+// runTagUpdate rewrites the tags line of the alias note, leaving the rest of the note untouched
+func runTagUpdate(args []string, update func(note string, tags ...string) string) {
+	defer utils.RecoverFunc()
+
+	tagList, err := normalizeTags(args[1:])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	alias, err := findAlias(client, args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	note := update(alias.Note, tagList...)
+	if note == alias.Note {
+		return
+	}
+
+	if err := client.UpdateAlias(alias.ID, simplelogin.AliasUpdateOptions{Note: &note}); err != nil {
+		log.Fatal(err)
+	}
+
+	journal.Warn(journal.Entry{
+		Action:  journal.ActionAliasUpdate,
+		AliasID: alias.ID,
+		Subject: alias.Email,
+		Before:  journal.State{Note: &alias.Note},
+	})
+}
+
+// normalizeTags validates the tags given on the command line
+func normalizeTags(values []string) ([]string, error) {
+	var result []string
+	for _, value := range values {
+		tag, err := tags.Normalize(value)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result, nil
+}
+
+// filterTagged keeps the aliases carrying every given tag
+func filterTagged(aliases []simplelogin.Alias, tagList []string) []simplelogin.Alias {
+	if len(tagList) == 0 {
+		return aliases
+	}
+
+	var result []simplelogin.Alias
+	for _, alias := range aliases {
+		if tags.HasAll(alias.Note, tagList) {
+			result = append(result, alias)
+		}
+	}
+	return result
+}
+
+const tagDescription = `
+Manage alias tags
+
+Tags are kept in a "#tags: a,b" line of the alias note, so they work without
+any server-side support. The rest of the note is left untouched.
+
+Filter by tag with 'simplelogin-cli alias list --tag work'.
+
+`
+
+const tagAddDescription = `
+Add tags to an alias
+
+Example:
+
+    simplelogin-cli alias tag add shop@example.com shopping work
+
+`
+
+const tagRemoveDescription = `
+Remove tags from an alias
+
+`
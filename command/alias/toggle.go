@@ -6,19 +6,22 @@ import (
 	"strconv"
 
 	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/journal"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
 	"github.com/spf13/cobra"
 )
 
 func newToggleCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "toggle [alias_id]",
-		Aliases: []string{"t"},
-		Short:   "Toggle alias",
-		Long:    toggleDescription,
-		Args:    cobra.ExactArgs(1),
+		Use:               "toggle [alias_id]",
+		Aliases:           []string{"t"},
+		Short:             "Toggle alias",
+		Long:              toggleDescription,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.AliasIDs),
 		Run: func(cmd *cobra.Command, args []string) {
 			runToggle(outputFormat, args)
 		},
@@ -57,6 +60,13 @@ func runToggle(outputFormat *string, args []string) {
 		log.Fatal(err)
 	}
 
+	wasEnabled := !alias.Enabled
+	journal.Warn(journal.Entry{
+		Action:  journal.ActionAliasToggle,
+		AliasID: aliasID,
+		Before:  journal.State{Enabled: &wasEnabled},
+	})
+
 	switch *outputFormat {
 	case "json":
 		if err := display.DisplayData(alias, &display.DisplayOptions{
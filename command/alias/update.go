@@ -5,9 +5,12 @@ import (
 	"strconv"
 
 	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/journal"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -18,14 +21,20 @@ var (
 	pinned     bool
 )
 
+// updateFlags are the flags of the alias fields 'alias update' changes
+var updateFlags = []string{"note", "name", "mailbox-ids", "disable-pgp", "pinned"}
+
 func newUpdateCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "update [alias_id]",
-		Aliases: []string{"up"},
-		Short:   "Update alias",
-		Long:    updateDescription,
-		Args:    cobra.ExactArgs(1),
-		Run:     runUpdate,
+		Use:               "update [alias_id]",
+		Aliases:           []string{"up"},
+		Short:             "Update alias",
+		Long:              updateDescription,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.AliasIDs),
+		Run: func(cmd *cobra.Command, args []string) {
+			runUpdate(cmd.Flags(), args)
+		},
 	}
 
 	flags := cmd.Flags()
@@ -37,15 +46,25 @@ func newUpdateCommand() *cobra.Command {
 	flags.BoolVarP(&disablePGP, "disable-pgp", "d", false, "Disable PGP")
 	flags.BoolVarP(&pinned, "pinned", "p", false, "Pinned")
 
+	cmd.RegisterFlagCompletionFunc("mailbox-ids", completion.List(completion.MailboxIDs))
+
 	return cmd
 }
 
-func runUpdate(cmd *cobra.Command, args []string) {
+func runUpdate(flags *pflag.FlagSet, args []string) {
 	defer utils.RecoverFunc()
 
-	if note == "" && name == "" && len(mailboxIds) == 0 && !disablePGP && !pinned {
+	// NFlag would also count inherited flags such as --output or --profile
+	changed := false
+	for _, flag := range updateFlags {
+		changed = changed || flags.Changed(flag)
+	}
+	if !changed {
 		log.Fatal("No update provided")
 	}
+	if flags.Changed("mailbox-ids") && len(mailboxIds) == 0 {
+		log.Fatal("--mailbox-ids needs at least one mailbox")
+	}
 
 	cfg, err := config.Load()
 	if err != nil {
@@ -67,30 +86,64 @@ func runUpdate(cmd *cobra.Command, args []string) {
 		log.Fatal(err)
 	}
 
+	alias, err := client.GetAlias(aliasID)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// The previous value of each changed field, for 'undo'
+	var before journal.State
+
 	aliasInput := simplelogin.AliasUpdateOptions{}
-	if note != "" {
-		aliasInput.Note = note
+	if flags.Changed("note") {
+		aliasInput.Note = &note
+		before.Note = &alias.Note
 	}
-	if name != "" {
-		aliasInput.Name = name
+	if flags.Changed("name") {
+		aliasInput.Name = &name
+		before.Name = &alias.Name
 	}
-	if len(mailboxIds) > 0 {
+	if flags.Changed("mailbox-ids") {
 		aliasInput.MailboxIDs = mailboxIds
+		before.MailboxIDs = mailboxIDs(alias.Mailboxes)
 	}
-	if disablePGP {
-		aliasInput.DisablePGP = true
+	if flags.Changed("disable-pgp") {
+		aliasInput.DisablePGP = &disablePGP
 	}
-	if pinned {
-		aliasInput.Pinned = true
+	if flags.Changed("pinned") {
+		aliasInput.Pinned = &pinned
+		before.Pinned = &alias.Pinned
 	}
 
 	err = client.UpdateAlias(aliasID, aliasInput)
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	// Only --disable-pgp changed, which 'undo' cannot restore
+	if before.IsEmpty() {
+		return
+	}
+
+	journal.Warn(journal.Entry{
+		Action:  journal.ActionAliasUpdate,
+		AliasID: aliasID,
+		Subject: alias.Email,
+		Before:  before,
+	})
+}
+
+func mailboxIDs(mailboxes []simplelogin.Mailbox) []int {
+	ids := make([]int, len(mailboxes))
+	for i, mailbox := range mailboxes {
+		ids[i] = mailbox.ID
+	}
+	return ids
 }
 
 const updateDescription = `
 Update alias
 
+The previous values are saved so that 'simplelogin-cli undo' can revert the
+update, except --disable-pgp which the API does not return.
 `
@@ -0,0 +1,210 @@
+package alias
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/mailwait"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	waitMailTimeout  time.Duration
+	waitMailInterval time.Duration
+	waitMailSince    time.Duration
+	waitMailExtract  string
+	waitMailPattern  string
+	waitMailMailbox  string
+)
+
+func newWaitMailCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "wait-mail [alias_id | alias_email]",
+		Short:             "Wait for mail delivered to an alias",
+		Long:              waitMailDescription,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.AliasEmails),
+		Run: func(cmd *cobra.Command, args []string) {
+			runWaitMail(outputFormat, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+
+	flags.DurationVar(&waitMailTimeout, "timeout", 2*time.Minute, "How long to wait for the message")
+	flags.DurationVar(&waitMailInterval, "interval", 5*time.Second, "Delay between two mailbox checks")
+	flags.DurationVar(&waitMailSince, "since", 0, "Also accept messages received this long before the command started")
+	flags.StringVarP(&waitMailExtract, "extract", "x", "", "Print only part of the message (subject, body, link, otp)")
+	flags.StringVar(&waitMailPattern, "pattern", "", "Print the first match of this regular expression (or its first group)")
+	flags.StringVar(&waitMailMailbox, "imap", "", "IMAP configuration to use, by mailbox email (default: the alias mailbox)")
+
+	return cmd
+}
+
+func runWaitMail(outputFormat *string, args []string) {
+	defer utils.RecoverFunc()
+
+	var pattern *regexp.Regexp
+	switch waitMailExtract {
+	case "", "subject", "body", "otp":
+	case "link":
+		pattern = mailwait.LinkPattern
+	default:
+		log.Fatalf("Unknown --extract value %q (subject, body, link, otp)", waitMailExtract)
+	}
+	if waitMailPattern != "" {
+		var err error
+		if pattern, err = regexp.Compile(waitMailPattern); err != nil {
+			log.Fatalf("Invalid --pattern: %v", err)
+		}
+	}
+
+	started := time.Now()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	alias, err := findAlias(client, args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	imapConfig, err := imapConfigForAlias(cfg, alias)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	password, err := imapConfig.LoadPassword()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), waitMailTimeout)
+	defer cancel()
+
+	msg, err := mailwait.Wait(ctx, mailwait.Config{
+		Address:  imapConfig.Address,
+		Username: imapConfig.Username,
+		Password: password,
+		TLS:      imapConfig.UseTLS(),
+		Folder:   imapConfig.Folder,
+	}, mailwait.Options{
+		Recipient:    alias.Email,
+		Since:        started.Add(-waitMailSince),
+		PollInterval: waitMailInterval,
+	})
+	if err != nil {
+		log.Fatalf("No mail for %s: %v", alias.Email, err)
+	}
+
+	if pattern != nil {
+		value, ok := mailwait.Extract(msg.Body, pattern)
+		if !ok {
+			log.Fatalf("Message %q does not match %s", msg.Subject, pattern)
+		}
+		fmt.Println(value)
+		return
+	}
+
+	switch {
+	case waitMailExtract == "otp":
+		value, ok := mailwait.ExtractOTP(msg.Body)
+		if !ok {
+			log.Fatalf("No one-time code found in message %q", msg.Subject)
+		}
+		fmt.Println(value)
+	case waitMailExtract == "subject":
+		fmt.Println(msg.Subject)
+	case waitMailExtract == "body":
+		fmt.Println(msg.Body)
+	case *outputFormat == "json":
+		if err := display.DisplayData(msg, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		fmt.Printf("Date: %s\n", msg.Date.Format("2006-01-02 15:04:05"))
+		fmt.Printf("From: %s\n", msg.From)
+		fmt.Printf("To: %s\n", msg.To)
+		fmt.Printf("Subject: %s\n", msg.Subject)
+		fmt.Printf("\n%s\n", msg.Body)
+	}
+}
+
+// imapConfigForAlias picks the IMAP configuration of the mailbox receiving the alias
+func imapConfigForAlias(cfg *config.Config, alias *simplelogin.Alias) (config.IMAPConfig, error) {
+	if waitMailMailbox != "" {
+		if imapConfig, ok := cfg.IMAPConfigFor(waitMailMailbox); ok {
+			return imapConfig, nil
+		}
+		return config.IMAPConfig{}, fmt.Errorf("no IMAP configuration for %s", waitMailMailbox)
+	}
+
+	mailboxes := append([]simplelogin.Mailbox{alias.Mailbox}, alias.Mailboxes...)
+	for _, mailbox := range mailboxes {
+		if imapConfig, ok := cfg.IMAPConfigFor(mailbox.Email); ok {
+			return imapConfig, nil
+		}
+	}
+
+	return config.IMAPConfig{}, fmt.Errorf("no IMAP configuration for the mailboxes of %s", alias.Email)
+}
+
+const waitMailDescription = `
+Wait for mail delivered to an alias
+
+The IMAP mailbox receiving the alias is polled until a message addressed to
+the alias arrives, then the message, or the part selected with --extract or
+--pattern, is printed.
+
+IMAP access is configured per mailbox in ~/.config/simplelogin-cli/config.json:
+
+    {
+      "imap": {
+        "qa@example.com": {
+          "address": "imap.example.com:993",
+          "username": "qa@example.com",
+          "password_command": "pass show imap/qa"
+        }
+      }
+    }
+
+The password can also be set with SIMPLELOGIN_IMAP_PASSWORD. Set "tls" to
+false for servers without implicit TLS.
+
+Only messages received after the command started are accepted. Use --since to
+also accept recent ones, keeping in mind that an older message, such as the
+code of an earlier signup, may then be returned.
+
+--extract otp prints the digits next to a word like "code" or "OTP", or else
+a lone 6 digit number.
+
+Examples:
+
+    simplelogin-cli alias wait-mail signup.xyz@aleeas.com --extract otp
+    simplelogin-cli alias wait-mail 1234 --pattern 'token=([a-z0-9]+)'
+
+`
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+func newActivateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "activate [email] [code]",
+		Short: "Activate a registered account",
+		Long:  activateDescription,
+		Args:  cobra.ExactArgs(2),
+		Run:   runActivate,
+	}
+
+	return cmd
+}
+
+func runActivate(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client := simplelogin.NewAnonymousClient(cfg.ApiURL)
+
+	result, err := client.Activate(args[0], args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(result.Msg)
+}
+
+const activateDescription = `
+Activate a registered account with the code received by email
+
+`
@@ -4,7 +4,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func NewCommand() *cobra.Command {
+var (
+	compact bool
+)
+
+func NewCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "auth",
 		Short: "Manage auth",
@@ -15,7 +19,15 @@ func NewCommand() *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		newActivateCommand(),
+		newForgotPasswordCommand(),
+		newLogoutCommand(),
+		newMigrateStorageCommand(),
+		newReactivateCommand(),
+		newRegisterCommand(),
+		newRotateCommand(),
 		newSetKeyCommand(),
+		newStatusCommand(outputFormat),
 	)
 
 	return cmd
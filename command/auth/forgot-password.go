@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+func newForgotPasswordCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "forgot-password [email]",
+		Short: "Request a password reset",
+		Long:  forgotPasswordDescription,
+		Args:  cobra.ExactArgs(1),
+		Run:   runForgotPassword,
+	}
+
+	return cmd
+}
+
+func runForgotPassword(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client := simplelogin.NewAnonymousClient(cfg.ApiURL)
+
+	if err := client.ForgotPassword(args[0]); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println("If the account exists, a password reset email has been sent")
+}
+
+const forgotPasswordDescription = `
+Request a password reset email
+
+`
@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+func newLogoutCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logout",
+		Short: "Log out and remove the stored API key",
+		Long:  logoutDescription,
+		Args:  cobra.NoArgs,
+		Run:   runLogout,
+	}
+
+	return cmd
+}
+
+func runLogout(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := checkStoredApiKey(); err != nil {
+		log.Fatalf("%v; remove it there to log out", err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := client.Logout(); err != nil {
+		fmt.Printf("⚠️  Warning: server logout failed: %v\n", err)
+	}
+
+	if err := config.DeleteApiKey(); err != nil {
+		log.Fatalf("Failed to delete API key: %v", err)
+	}
+
+	fmt.Println("Logged out, API key removed")
+}
+
+const logoutDescription = `
+Log out and remove the stored API key
+
+The API key itself stays valid on the server; revoke it from the web
+dashboard (Settings > API Keys) if it may have leaked. A key read from
+SIMPLELOGIN_API_KEY or api_key_command is left to its owner and refused.
+
+`
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var (
+	migrateStorageFrom string
+	migrateStorageTo   string
+)
+
+func newMigrateStorageCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate-storage",
+		Short: "Move the API key between storage backends",
+		Long:  migrateStorageDescription,
+		Args:  cobra.NoArgs,
+		Run:   runMigrateStorage,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&migrateStorageFrom, "from", "", "Source backend (keyring, file); defaults to the current one")
+	flags.StringVar(&migrateStorageTo, "to", "", "Destination backend (keyring, file)")
+	cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runMigrateStorage(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	from := migrateStorageFrom
+	if from == "" {
+		backend, _, err := config.ApiKeyBackend()
+		if err != nil {
+			log.Fatal(err)
+		}
+		from = backend
+	}
+
+	if from != config.BackendKeyring && from != config.BackendFile {
+		log.Fatalf("Cannot migrate from the %s backend", from)
+	}
+
+	apiKey, err := config.LoadApiKeyFrom(from)
+	if err != nil {
+		log.Fatalf("Failed to read API key from %s: %v", from, err)
+	}
+
+	if err := config.SaveApiKeyTo(migrateStorageTo, apiKey); err != nil {
+		log.Fatalf("Failed to write API key to %s: %v", migrateStorageTo, err)
+	}
+
+	stored, err := config.LoadApiKeyFrom(migrateStorageTo)
+	if err != nil || stored != apiKey {
+		log.Fatalf("API key could not be read back from %s, leaving %s untouched", migrateStorageTo, from)
+	}
+
+	// Migrating a backend onto itself re-encodes it, e.g. to encrypt a clear text file
+	if from != migrateStorageTo {
+		if err := config.DeleteApiKeyFrom(from); err != nil {
+			log.Fatalf("API key copied to %s but could not be removed from %s: %v", migrateStorageTo, from, err)
+		}
+	}
+
+	fmt.Printf("API key moved from %s to %s\n", from, migrateStorageTo)
+}
+
+const migrateStorageDescription = `
+Move the API key between storage backends
+
+Backends:
+  keyring   the operating system keyring
+  file      ~/.config/simplelogin-cli/credentials.json, encrypted with a
+            passphrase read from SIMPLELOGIN_PASSPHRASE or prompted for
+
+Running with --to file on an existing clear text credentials file encrypts it.
+
+An external helper can be used instead of any storage by setting
+"api_key_command" in ~/.config/simplelogin-cli/config.json, for example:
+
+    { "api_key_command": "pass show simplelogin" }
+
+`
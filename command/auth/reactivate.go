@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+func newReactivateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "reactivate [email]",
+		Short: "Send a new activation code",
+		Long:  reactivateDescription,
+		Args:  cobra.ExactArgs(1),
+		Run:   runReactivate,
+	}
+
+	return cmd
+}
+
+func runReactivate(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client := simplelogin.NewAnonymousClient(cfg.ApiURL)
+
+	result, err := client.Reactivate(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(result.Msg)
+}
+
+const reactivateDescription = `
+Send a new activation code to an account that is not activated yet
+
+`
@@ -0,0 +1,56 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/prompt"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+func newRegisterCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "register [email]",
+		Short: "Register a new account",
+		Long:  registerDescription,
+		Args:  cobra.ExactArgs(1),
+		Run:   runRegister,
+	}
+
+	return cmd
+}
+
+func runRegister(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	password, err := prompt.NewPassword("Password: ")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client := simplelogin.NewAnonymousClient(cfg.ApiURL)
+
+	result, err := client.Register(args[0], password)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(result.Msg)
+	fmt.Println("Use 'simplelogin-cli auth activate' with the code sent by email to activate the account")
+}
+
+const registerDescription = `
+Register a new account
+
+The password is read from the terminal. An activation code is sent to the
+given email address.
+
+`
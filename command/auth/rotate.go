@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rotateDevice string
+	rotatePrint  bool
+)
+
+func newRotateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace the stored API key with a new one",
+		Long:  rotateDescription,
+		Args:  cobra.NoArgs,
+		Run:   runRotate,
+	}
+
+	cmd.Flags().StringVar(&rotateDevice, "device", defaultDevice(), "Device name attached to the new API key")
+	cmd.Flags().BoolVar(&rotatePrint, "print", false, "Print the new API key instead of storing it")
+
+	return cmd
+}
+
+func runRotate(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if !rotatePrint {
+		if err := checkStoredApiKey(); err != nil {
+			log.Fatalf("%v; pass --print and store the new key there", err)
+		}
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	newApiKey, err := client.CreateApiKey(rotateDevice)
+	if err != nil {
+		log.Fatalf("Failed to create API key: %v", err)
+	}
+
+	// Make sure the new key works before replacing the old one
+	newClient, err := simplelogin.NewClient(cfg.ApiURL, newApiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := newClient.GetUserInfo(); err != nil {
+		log.Fatalf("New API key is not valid, keeping the current one: %v", err)
+	}
+
+	if rotatePrint {
+		fmt.Println(newApiKey)
+		fmt.Fprintln(os.Stderr, "Store the new API key, then revoke the previous one from the web dashboard (Settings > API Keys)")
+		return
+	}
+
+	if err := config.SaveApiKey(newApiKey); err != nil {
+		log.Fatalf("Failed to save API key, keeping the current one: %v", err)
+	}
+
+	fmt.Printf("API key rotated (device: %s)\n", rotateDevice)
+	fmt.Println("Revoke the previous key from the web dashboard (Settings > API Keys)")
+}
+
+// checkStoredApiKey refuses to replace or delete the API key when it comes
+// from the environment or a credential helper, which this tool cannot change
+func checkStoredApiKey() error {
+	backend, location, err := config.ApiKeyBackend()
+	if err != nil {
+		return err
+	}
+
+	switch backend {
+	case config.BackendEnv:
+		return fmt.Errorf("the API key comes from $%s", location)
+	case config.BackendCommand:
+		return fmt.Errorf("the API key comes from api_key_command (%s)", location)
+	}
+	return nil
+}
+
+func defaultDevice() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "simplelogin-cli"
+	}
+	return fmt.Sprintf("simplelogin-cli@%s", hostname)
+}
+
+const rotateDescription = `
+Create a new API key and swap it in place of the stored one
+
+The new key is validated before it replaces the current key, so a failure
+leaves the existing configuration untouched.
+
+A key read from SIMPLELOGIN_API_KEY or api_key_command cannot be replaced:
+pass --print to get the new key and store it there.
+
+`
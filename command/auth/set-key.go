@@ -1,37 +1,102 @@
 package auth
 
 import (
+	"bufio"
 	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/juli3nk/go-utils"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/prompt"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
 	"github.com/spf13/cobra"
 )
 
+var (
+	setKeyStdin bool
+)
+
 func newSetKeyCommand() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "set-key [key]",
+		Use:   "set-key",
 		Short: "Set API key",
 		Long:  setApiKeyDescription,
-		Args:  cobra.ExactArgs(1),
 		Run:   runSetApiKey,
 	}
 
+	cmd.Flags().BoolVar(&setKeyStdin, "stdin", false, "Read the API key from stdin")
+
 	return cmd
 }
 
 func runSetApiKey(cmd *cobra.Command, args []string) {
 	defer utils.RecoverFunc()
 
-	if err := config.SaveApiKey(args[0]); err != nil {
+	// An argument would land in shell history and process listings
+	if len(args) > 0 {
+		log.Fatal("The API key is not accepted as an argument, pipe it with --stdin or enter it at the prompt")
+	}
+
+	apiKey, err := readApiKey()
+	if err != nil {
+		log.Fatalf("Failed to read API key: %v", err)
+	}
+	if apiKey == "" {
+		log.Fatal("API key is empty")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	userInfo, err := client.GetUserInfo()
+	if err != nil {
+		log.Fatalf("API key rejected, not saved: %v", err)
+	}
+
+	if err := config.SaveApiKey(apiKey); err != nil {
 		log.Fatalf("Failed to save API key: %v", err)
 	}
 
-	fmt.Println("API key saved successfully")
+	fmt.Printf("API key saved successfully (%s)\n", userInfo.Email)
+}
+
+// readApiKey reads the API key from the first available source:
+// stdin, environment, then an interactive prompt
+func readApiKey() (string, error) {
+	if setKeyStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", err
+		}
+		return strings.TrimSpace(line), nil
+	}
+
+	if apiKey := os.Getenv(config.EnvApiKey); apiKey != "" {
+		return strings.TrimSpace(apiKey), nil
+	}
+
+	apiKey, err := prompt.Password("API key: ")
+	return strings.TrimSpace(apiKey), err
 }
 
 const setApiKeyDescription = `
 Set API Key
 
+The key is read from, in order: the --stdin flag, the SIMPLELOGIN_API_KEY
+environment variable, or a hidden prompt. It is validated against the API
+before being saved. The key is never accepted as an argument, where it would
+be exposed in shell history and process listings.
+
+Every command also honours SIMPLELOGIN_API_KEY and SIMPLELOGIN_API_URL
+directly, without reading the keyring or the configuration file.
+
 `
@@ -0,0 +1,105 @@
+package auth
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+// Status represents the authentication status
+type Status struct {
+	ApiURL    string `json:"api_url"`
+	Backend   string `json:"backend"`
+	Location  string `json:"location"`
+	Email     string `json:"email"`
+	Name      string `json:"name"`
+	IsPremium bool   `json:"is_premium"`
+	InTrial   bool   `json:"in_trial"`
+}
+
+func newStatusCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "status",
+		Aliases: []string{"whoami"},
+		Short:   "Show authentication status",
+		Long:    statusDescription,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runStatus(outputFormat)
+		},
+	}
+
+	cmd.Flags().BoolVar(&compact, "compact", false, "Compact output")
+
+	return cmd
+}
+
+func runStatus(outputFormat *string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	backend, location, err := config.ApiKeyBackend()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	userInfo, err := client.GetUserInfo()
+	if err != nil {
+		log.Fatalf("API key stored in %s is not valid: %v", backend, err)
+	}
+
+	status := Status{
+		ApiURL:    simplelogin.BaseURL,
+		Backend:   backend,
+		Location:  location,
+		Email:     userInfo.Email,
+		Name:      userInfo.Name,
+		IsPremium: userInfo.IsPremium,
+		InTrial:   userInfo.InTrial,
+	}
+	if cfg.ApiURL != nil {
+		status.ApiURL = *cfg.ApiURL
+	}
+
+	switch *outputFormat {
+	case "json":
+		if err := display.DisplayData(status, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		fmt.Printf("Logged in to %s as %s (%s)\n", status.ApiURL, status.Email, status.Name)
+		fmt.Printf("Is Premium: %t\n", status.IsPremium)
+		fmt.Printf("In Trial: %t\n", status.InTrial)
+		fmt.Printf("Key Storage: %s (%s)\n", status.Backend, status.Location)
+	}
+}
+
+const statusDescription = `
+Show which account the stored API key belongs to
+
+The key is validated against the API and the storage backend (keyring or
+credentials file) is reported.
+
+`
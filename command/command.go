@@ -5,12 +5,22 @@ import (
 
 	"github.com/juli3nk/simplelogin-cli/command/alias"
 	"github.com/juli3nk/simplelogin-cli/command/auth"
+	"github.com/juli3nk/simplelogin-cli/command/completion"
 	"github.com/juli3nk/simplelogin-cli/command/contact"
+	"github.com/juli3nk/simplelogin-cli/command/dashboard"
 	"github.com/juli3nk/simplelogin-cli/command/domain"
 	"github.com/juli3nk/simplelogin-cli/command/mailbox"
+	"github.com/juli3nk/simplelogin-cli/command/mcp"
+	"github.com/juli3nk/simplelogin-cli/command/migrate"
+	"github.com/juli3nk/simplelogin-cli/command/notification"
+	"github.com/juli3nk/simplelogin-cli/command/serve"
 	"github.com/juli3nk/simplelogin-cli/command/setting"
+	"github.com/juli3nk/simplelogin-cli/command/snapshot"
 	"github.com/juli3nk/simplelogin-cli/command/stats"
+	"github.com/juli3nk/simplelogin-cli/command/tui"
+	"github.com/juli3nk/simplelogin-cli/command/undo"
 	"github.com/juli3nk/simplelogin-cli/command/userinfo"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
 )
 
 var usageTemplate = `{{ .Short | trim }}
@@ -43,27 +53,43 @@ Use "{{ .CommandPath }} [command] --help" for more information about a command.{
 var helpTemplate = `
 {{ if or .Runnable .HasSubCommands }}{{ .UsageString }}{{ end }}`
 
-var outputFormat string
+var (
+	outputFormat string
+	profile      string
+)
 
 func NewSimpleLoginCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "simplelogin-cli",
 		Short: "SimpleLogin CLI",
 		Long:  "SimpleLogin CLI",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return config.SetProfile(profile)
+		},
 	}
 
 	cmd.SetHelpTemplate(helpTemplate)
 	cmd.SetUsageTemplate(usageTemplate)
 
 	cmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	cmd.PersistentFlags().StringVar(&profile, "profile", "", "Configuration profile (default: $"+config.EnvProfile+" or default)")
 
 	cmd.AddCommand(alias.NewCommand(&outputFormat))
-	cmd.AddCommand(auth.NewCommand())
+	cmd.AddCommand(auth.NewCommand(&outputFormat))
+	cmd.AddCommand(completion.NewCommand())
 	cmd.AddCommand(contact.NewCommand(&outputFormat))
+	cmd.AddCommand(dashboard.NewCommand())
 	cmd.AddCommand(domain.NewCommand(&outputFormat))
 	cmd.AddCommand(mailbox.NewCommand(&outputFormat))
+	cmd.AddCommand(mcp.NewCommand())
+	cmd.AddCommand(migrate.NewCommand(&outputFormat))
+	cmd.AddCommand(notification.NewCommand(&outputFormat))
+	cmd.AddCommand(serve.NewCommand(&outputFormat))
 	cmd.AddCommand(setting.NewCommand(&outputFormat))
+	cmd.AddCommand(snapshot.NewCommand(&outputFormat))
 	cmd.AddCommand(stats.NewCommand(&outputFormat))
+	cmd.AddCommand(tui.NewCommand())
+	cmd.AddCommand(undo.NewCommand(&outputFormat))
 	cmd.AddCommand(userinfo.NewCommand(&outputFormat))
 
 	return cmd
@@ -0,0 +1,162 @@
+package completion
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/spf13/cobra"
+)
+
+var shells = []string{"bash", "zsh", "fish", "powershell"}
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "completion",
+		Short: "Generate or install shell completion scripts",
+		Long:  completionDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+
+	for _, shell := range shells {
+		cmd.AddCommand(&cobra.Command{
+			Use:                   shell,
+			Short:                 fmt.Sprintf("Generate the %s completion script", shell),
+			Args:                  cobra.NoArgs,
+			DisableFlagsInUseLine: true,
+			Run: func(cmd *cobra.Command, args []string) {
+				if err := generate(cmd.Root(), shell, os.Stdout); err != nil {
+					log.Fatal(err)
+				}
+			},
+		})
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:       "install [bash | zsh | fish]",
+		Short:     "Install the completion script for your shell",
+		Long:      installDescription,
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{"bash", "zsh", "fish"},
+		Run:       runInstall,
+	})
+
+	return cmd
+}
+
+func generate(root *cobra.Command, shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		return root.GenBashCompletionV2(w, true)
+	case "zsh":
+		return root.GenZshCompletion(w)
+	case "fish":
+		return root.GenFishCompletion(w, true)
+	case "powershell":
+		return root.GenPowerShellCompletionWithDesc(w)
+	default:
+		return fmt.Errorf("unsupported shell %q", shell)
+	}
+}
+
+func runInstall(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	shell := filepath.Base(os.Getenv("SHELL"))
+	if len(args) > 0 {
+		shell = args[0]
+	}
+
+	path, err := installPath(shell, cmd.Root().Name())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	if err := generate(cmd.Root(), shell, f); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Installed %s completion to %s\n", shell, path)
+
+	switch shell {
+	case "bash":
+		fmt.Println("Start a new shell to use it (requires the bash-completion package).")
+	case "zsh":
+		fmt.Printf("Make sure your ~/.zshrc contains, before compinit:\n  fpath=(%s $fpath)\n", filepath.Dir(path))
+	case "fish":
+		fmt.Println("Start a new shell to use it.")
+	}
+}
+
+// installPath returns where each shell loads user completion scripts from
+func installPath(shell, name string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	switch shell {
+	case "bash":
+		dataHome := os.Getenv("XDG_DATA_HOME")
+		if dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(dataHome, "bash-completion", "completions", name), nil
+	case "zsh":
+		return filepath.Join(home, ".zfunc", "_"+name), nil
+	case "fish":
+		configHome := os.Getenv("XDG_CONFIG_HOME")
+		if configHome == "" {
+			configHome = filepath.Join(home, ".config")
+		}
+		return filepath.Join(configHome, "fish", "completions", name+".fish"), nil
+	case "", ".":
+		return "", fmt.Errorf("cannot detect your shell, pass one of bash, zsh or fish")
+	default:
+		return "", fmt.Errorf("unsupported shell %q, pass one of bash, zsh or fish", shell)
+	}
+}
+
+const completionDescription = `
+Generate or install shell completion scripts
+
+Completion is dynamic: alias IDs and emails, mailboxes and custom domains
+are fetched from the API and cached for a minute under the user cache
+directory, so pressing TAB stays fast.
+
+Completion needs an API key from the environment, a credential helper or
+the keyring; it is disabled with the passphrase protected file backend.
+
+Examples:
+  simplelogin-cli completion install
+  simplelogin-cli completion bash > /etc/bash_completion.d/simplelogin-cli
+  source <(simplelogin-cli completion zsh)
+`
+
+const installDescription = `
+Install the completion script for your shell
+
+The shell is detected from $SHELL unless given. Scripts are written to:
+  bash  ~/.local/share/bash-completion/completions/simplelogin-cli
+  zsh   ~/.zfunc/_simplelogin-cli
+  fish  ~/.config/fish/completions/simplelogin-cli.fish
+
+Examples:
+  simplelogin-cli completion install
+  simplelogin-cli completion install zsh
+`
@@ -8,6 +8,7 @@ import (
 	"github.com/juli3nk/go-utils"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/journal"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
 	"github.com/spf13/cobra"
 )
@@ -57,6 +58,13 @@ func runBlock(outputFormat *string, args []string) {
 		log.Fatal(err)
 	}
 
+	wasBlocked := !contact.BlockForward
+	journal.Warn(journal.Entry{
+		Action:    journal.ActionContactBlock,
+		ContactID: contactID,
+		Before:    journal.State{Blocked: &wasBlocked},
+	})
+
 	switch *outputFormat {
 	case "json":
 		if err := display.DisplayData(contact, &display.DisplayOptions{
@@ -8,10 +8,13 @@ import (
 	"github.com/juli3nk/go-utils"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/prompt"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
 	"github.com/spf13/cobra"
 )
 
+var deleteYes bool
+
 func newDeleteCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "delete [contact_id]",
@@ -25,6 +28,7 @@ func newDeleteCommand(outputFormat *string) *cobra.Command {
 	}
 
 	cmd.Flags().BoolVar(&compact, "compact", false, "Compact output")
+	cmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Do not ask for confirmation")
 
 	return cmd
 }
@@ -52,6 +56,12 @@ func runDelete(outputFormat *string, args []string) {
 		log.Fatal(err)
 	}
 
+	// The API cannot look a contact up by ID, so only the ID can be shown
+	label := fmt.Sprintf("Delete contact %d? Its reverse alias stops working.", contactID)
+	if err := prompt.ConfirmAction(label, deleteYes); err != nil {
+		log.Fatal(err)
+	}
+
 	contact, err := client.DeleteContact(contactID)
 	if err != nil {
 		log.Fatal(err)
@@ -73,4 +83,6 @@ func runDelete(outputFormat *string, args []string) {
 const deleteDescription = `
 Delete contact
 
+A confirmation is asked first; pass --yes in scripts. Once deleted, replies
+sent through the contact's reverse alias are rejected.
 `
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
@@ -14,11 +15,12 @@ import (
 
 func newListCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "list [alias_id]",
-		Aliases: []string{"ls"},
-		Short:   "List contacts",
-		Long:    listDescription,
-		Args:    cobra.ExactArgs(1),
+		Use:               "list [alias_id]",
+		Aliases:           []string{"ls"},
+		Short:             "List contacts",
+		Long:              listDescription,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.AliasIDs),
 		Run: func(cmd *cobra.Command, args []string) {
 			runList(outputFormat, args)
 		},
@@ -0,0 +1,91 @@
+package dashboard
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"runtime"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	printOnly bool
+	next      string
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Open the web dashboard",
+		Long:  dashboardDescription,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runDashboard()
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&printOnly, "print", false, "Print the sign-in URL instead of opening it")
+	flags.StringVar(&next, "next", "/dashboard/", "Page to open after signing in")
+
+	return cmd
+}
+
+func runDashboard() {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	token, err := client.GetCookieToken()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	signInURL := client.DashboardSignInURL(token, next)
+
+	if printOnly {
+		fmt.Println(signInURL)
+		return
+	}
+
+	if err := openBrowser(signInURL); err != nil {
+		log.Fatalf("Failed to open browser: %v (use --print to get the URL)", err)
+	}
+
+	fmt.Println("Dashboard opened in your browser")
+}
+
+func openBrowser(url string) error {
+	name := "xdg-open"
+	if runtime.GOOS == "darwin" {
+		name = "open"
+	}
+
+	return exec.Command(name, url).Start()
+}
+
+const dashboardDescription = `
+Open the web dashboard, already signed in
+
+The API key is exchanged for a short-lived token, so no password or MFA
+code is needed. The token is single use and expires quickly: do not share
+the URL printed with --print.
+
+`
@@ -0,0 +1,206 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/dnsrecords"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	checkDNSResolver string
+	checkDNSTimeout  time.Duration
+)
+
+func newCheckDNSCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "check-dns [domain]",
+		Short:             "Check the DNS records of custom domains",
+		Long:              checkDNSDescription,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.DomainNames),
+		Run: func(cmd *cobra.Command, args []string) {
+			runCheckDNS(outputFormat, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+	flags.BoolVar(&noHeaders, "no-headers", false, "Hide table headers")
+
+	flags.StringVar(&checkDNSResolver, "resolver", "", "DNS server to query, host[:port] (default: system resolver)")
+	flags.DurationVar(&checkDNSTimeout, "timeout", 30*time.Second, "Timeout of all the lookups")
+
+	return cmd
+}
+
+func runCheckDNS(outputFormat *string, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	instance, err := instanceFor(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	domains, err := client.GetDomains()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	names, err := domainNames(domains, args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), checkDNSTimeout)
+	defer cancel()
+
+	resolver := dnsrecords.NewResolver(checkDNSResolver)
+
+	checks := []dnsrecords.Check{}
+	for _, name := range names {
+		checks = append(checks, dnsrecords.CheckDomain(ctx, resolver, instance, name)...)
+	}
+
+	failed := 0
+	for _, check := range checks {
+		if check.Status != dnsrecords.StatusPass {
+			failed++
+		}
+	}
+
+	switch *outputFormat {
+	case "json":
+		if err := display.DisplayData(checks, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default: // table
+		if len(checks) == 0 {
+			fmt.Println("No custom domains found.")
+			return
+		}
+
+		tableOpts := display.DefaultTableOptions()
+		if noHeaders {
+			tableOpts.NoHeaders = true
+		}
+		if compact {
+			tableOpts = display.CompactTableOptions()
+		}
+
+		table := display.NewTable(tableOpts)
+		table.SetHeader([]string{"Domain", "Check", "Status", "Found", "Detail"})
+
+		for _, check := range checks {
+			table.Append([]string{
+				check.Domain,
+				check.Name,
+				check.Status,
+				strings.Join(check.Found, "\n"),
+				check.Detail,
+			})
+		}
+
+		table.Render()
+
+		if failed > 0 {
+			fmt.Println("\nRecords to add or replace:")
+			for _, check := range checks {
+				for _, record := range check.Fix {
+					fmt.Printf("  %s\n", record)
+				}
+			}
+		}
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// instanceFor returns the mail setup custom domains need on the configured
+// instance, from the "dns" configuration or derived from the API URL
+func instanceFor(cfg *config.Config) (dnsrecords.Instance, error) {
+	if cfg.DNS != nil {
+		return *cfg.DNS, nil
+	}
+
+	apiURL := simplelogin.BaseURL
+	if cfg.ApiURL != nil && *cfg.ApiURL != "" {
+		apiURL = *cfg.ApiURL
+	}
+
+	instance, err := dnsrecords.ForAPIURL(apiURL)
+	if err != nil {
+		return instance, fmt.Errorf("%w, set \"dns\" in the configuration", err)
+	}
+	return instance, nil
+}
+
+// domainNames returns the custom domains to work on, all of them unless one is given
+func domainNames(domains []simplelogin.Domain, args []string) ([]string, error) {
+	var names []string
+	for _, domain := range domains {
+		if len(args) == 0 || strings.EqualFold(domain.DomainName, strings.TrimSuffix(args[0], ".")) {
+			names = append(names, domain.DomainName)
+		}
+	}
+
+	if len(args) > 0 && len(names) == 0 {
+		return nil, fmt.Errorf("%s is not a custom domain of this account", args[0])
+	}
+
+	return names, nil
+}
+
+const checkDNSDescription = `
+Check the DNS records of custom domains
+
+The MX, SPF, DKIM (dkim, dkim02 and dkim03 CNAMEs) and DMARC records of each
+custom domain, or of the given one, are compared with the records the
+configured instance expects. Failed checks list the exact records to add.
+The command exits with status 1 when a check fails.
+
+The expected records are derived from the API URL: mx1/mx2.simplelogin.co
+for the hosted service, the app host and its parent domain for a self-hosted
+instance. Override them in the configuration (or a profile) if needed:
+
+    "dns": {
+        "mx": [{"host": "mail.example.org", "priority": 10}],
+        "spf_include": "example.org",
+        "dkim_domain": "example.org"
+    }
+
+Examples:
+  simplelogin-cli domain check-dns
+  simplelogin-cli domain check-dns example.com --resolver 1.1.1.1
+  simplelogin-cli domain check-dns --resolver 127.0.0.1:5353
+`
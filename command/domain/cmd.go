@@ -20,9 +20,11 @@ func NewCommand(outputFormat *string) *cobra.Command {
 	}
 
 	cmd.AddCommand(
+		newCheckDNSCommand(outputFormat),
 		newListCommand(outputFormat),
 		newTrashCommand(outputFormat),
 		newUpdateCommand(outputFormat),
+		newZonefileCommand(),
 	)
 
 	return cmd
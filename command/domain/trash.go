@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
@@ -14,10 +15,11 @@ import (
 
 func newTrashCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "trash [domain_id]",
-		Short: "Trash domains",
-		Long:  trashDescription,
-		Args:  cobra.ExactArgs(1),
+		Use:               "trash [domain_id]",
+		Short:             "Trash domains",
+		Long:              trashDescription,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.DomainIDs),
 		Run: func(cmd *cobra.Command, args []string) {
 			runTrash(outputFormat, args)
 		},
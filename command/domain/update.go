@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
@@ -21,11 +22,12 @@ var (
 
 func newUpdateCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "update [domain_id]",
-		Aliases: []string{"up"},
-		Short:   "Update domain",
-		Long:    updateDescription,
-		Args:    cobra.ExactArgs(1),
+		Use:               "update [domain_id]",
+		Aliases:           []string{"up"},
+		Short:             "Update domain",
+		Long:              updateDescription,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.DomainIDs),
 		Run: func(cmd *cobra.Command, args []string) {
 			runUpdate(outputFormat, args)
 		},
@@ -39,6 +41,8 @@ func newUpdateCommand(outputFormat *string) *cobra.Command {
 	flags.StringVarP(&name, "name", "n", "", "Name")
 	flags.IntSliceVarP(&mailboxIds, "mailbox-ids", "m", []int{}, "Mailbox IDs")
 
+	cmd.RegisterFlagCompletionFunc("mailbox-ids", completion.List(completion.MailboxIDs))
+
 	return cmd
 }
 
@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/dnsrecords"
+	"github.com/spf13/cobra"
+)
+
+var (
+	zonefileFormat string
+	zonefileTTL    int
+)
+
+func newZonefileCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "zonefile <domain>",
+		Short:             "Print the DNS records of a custom domain as a zone snippet",
+		Long:              zonefileDescription,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.DomainNames),
+		Run: func(cmd *cobra.Command, args []string) {
+			runZonefile(args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&zonefileFormat, "format", dnsrecords.FormatBind, fmt.Sprintf("Snippet format (%s)", strings.Join(dnsrecords.Formats, ", ")))
+	flags.IntVar(&zonefileTTL, "ttl", dnsrecords.DefaultTTL, "TTL of the records, in seconds")
+
+	cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(dnsrecords.Formats, cobra.ShellCompDirectiveNoFileComp))
+
+	return cmd
+}
+
+func runZonefile(args []string) {
+	defer utils.RecoverFunc()
+
+	if zonefileTTL <= 0 {
+		log.Fatal("--ttl must be positive")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	instance, err := instanceFor(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	domain := strings.ToLower(strings.TrimSuffix(args[0], "."))
+
+	if err := dnsrecords.WriteZone(os.Stdout, zonefileFormat, domain, instance.Expected(domain), zonefileTTL); err != nil {
+		log.Fatal(err)
+	}
+}
+
+const zonefileDescription = `
+Print the DNS records of a custom domain as a zone snippet
+
+The MX, SPF, DKIM (dkim, dkim02 and dkim03 CNAMEs) and DMARC records the
+configured instance expects are printed in a format ready to paste into a
+DNS repository:
+
+    bind       zone file lines, relative to $ORIGIN
+    terraform  aws_route53_record resources, using var.zone_id
+    octodns    octoDNS zone YAML
+
+The records are the ones "domain check-dns" verifies, derived from the API
+URL or the "dns" configuration. The domain does not need to be added to the
+account yet. A domain has a single SPF record: merge the include into an
+existing one rather than adding a second record.
+
+Examples:
+  simplelogin-cli domain zonefile example.com
+  simplelogin-cli domain zonefile example.com --format terraform > simplelogin.tf
+  simplelogin-cli domain zonefile example.com --format octodns --ttl 300
+`
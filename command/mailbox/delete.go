@@ -3,26 +3,34 @@ package mailbox
 import (
 	"fmt"
 	"log"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
 	"github.com/juli3nk/simplelogin-cli/internal/config"
 	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/prompt"
+	"github.com/juli3nk/simplelogin-cli/internal/protect"
 	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
 	"github.com/spf13/cobra"
 )
 
 var (
 	transferAliasesTo string
+	deleteAliases     bool
+	deleteYes         bool
 )
 
 func newDeleteCommand(outputFormat *string) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:     "delete [mailbox_id]",
-		Aliases: []string{"del"},
-		Short:   "Delete mailbox",
-		Long:    deleteDescription,
-		Args:    cobra.ExactArgs(1),
+		Use:               "delete [mailbox_id]",
+		Aliases:           []string{"del"},
+		Short:             "Delete mailbox",
+		Long:              deleteDescription,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.FirstArg(completion.MailboxIDs),
 		Run: func(cmd *cobra.Command, args []string) {
 			runDelete(outputFormat, args)
 		},
@@ -30,7 +38,11 @@ func newDeleteCommand(outputFormat *string) *cobra.Command {
 
 	cmd.Flags().BoolVar(&compact, "compact", false, "Compact output")
 
-	cmd.Flags().StringVarP(&transferAliasesTo, "transfer-aliases-to", "t", "", "Transfer aliases to mailbox email")
+	cmd.Flags().StringVarP(&transferAliasesTo, "transfer-aliases-to", "t", "", "Transfer aliases to this mailbox, by email or ID")
+	cmd.Flags().BoolVar(&deleteAliases, "delete-aliases", false, "Delete the aliases owned by this mailbox only")
+	cmd.Flags().BoolVarP(&deleteYes, "yes", "y", false, "Do not ask for confirmation")
+
+	cmd.RegisterFlagCompletionFunc("transfer-aliases-to", completion.MailboxEmails)
 
 	return cmd
 }
@@ -38,6 +50,10 @@ func newDeleteCommand(outputFormat *string) *cobra.Command {
 func runDelete(outputFormat *string, args []string) {
 	defer utils.RecoverFunc()
 
+	if transferAliasesTo != "" && deleteAliases {
+		log.Fatal("--transfer-aliases-to and --delete-aliases are mutually exclusive")
+	}
+
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatal(err)
@@ -58,13 +74,66 @@ func runDelete(outputFormat *string, args []string) {
 		log.Fatal(err)
 	}
 
-	transferAliasesToID, err := strconv.Atoi(transferAliasesTo)
+	rules, err := protect.New(cfg.Protect)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	mailboxes, err := client.GetMailboxes()
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	mailboxDeleteOptions := simplelogin.MailboxDeleteOptions{
-		TransferAliasesTo: &transferAliasesToID,
+	mailbox := findMailbox(mailboxes, strconv.Itoa(mailboxID))
+	if mailbox == nil {
+		log.Fatalf("mailbox %d not found", mailboxID)
+	}
+
+	mailboxDeleteOptions := simplelogin.MailboxDeleteOptions{}
+	transferTo := ""
+	if transferAliasesTo != "" {
+		target := findMailbox(mailboxes, transferAliasesTo)
+		if target == nil {
+			log.Fatalf("mailbox %s not found", transferAliasesTo)
+		}
+		if target.ID == mailboxID {
+			log.Fatal("cannot transfer aliases to the mailbox being deleted")
+		}
+		mailboxDeleteOptions.TransferAliasesTo = &target.ID
+		transferTo = target.Email
+	}
+
+	aliases, err := client.GetAllAliases(simplelogin.AliasListOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Aliases also owned by another mailbox keep working, the others are
+	// transferred or deleted with the mailbox
+	var affected []simplelogin.Alias
+	for _, alias := range aliases {
+		if ownedOnlyBy(alias, mailboxID) {
+			affected = append(affected, alias)
+		}
+	}
+
+	if transferTo == "" && !deleteAliases && len(affected) > 0 {
+		log.Fatalf("%d aliases are owned by %s only, pass --transfer-aliases-to <mailbox> to keep them or --delete-aliases to delete them", len(affected), mailbox.Email)
+	}
+
+	if transferTo == "" {
+		for _, alias := range affected {
+			if err := rules.Check(alias); err != nil {
+				log.Fatalf("%v, use --transfer-aliases-to to keep it", err)
+			}
+		}
+	}
+
+	if !deleteYes && prompt.IsTerminal() {
+		printMailboxSummary(mailbox, affected, transferTo)
+	}
+	if err := prompt.ConfirmAction(fmt.Sprintf("Delete mailbox %s?", mailbox.Email), deleteYes); err != nil {
+		log.Fatal(err)
 	}
 
 	err = client.DeleteMailbox(mailboxID, mailboxDeleteOptions)
@@ -85,7 +154,59 @@ func runDelete(outputFormat *string, args []string) {
 	}
 }
 
+// ownedOnlyBy reports whether a mailbox is the only owner of an alias
+func ownedOnlyBy(alias simplelogin.Alias, mailboxID int) bool {
+	mailboxes := alias.Mailboxes
+	if len(mailboxes) == 0 {
+		mailboxes = []simplelogin.Mailbox{alias.Mailbox}
+	}
+	return len(mailboxes) == 1 && mailboxes[0].ID == mailboxID
+}
+
+// findMailbox finds a mailbox by ID or email
+func findMailbox(mailboxes []simplelogin.Mailbox, ref string) *simplelogin.Mailbox {
+	ref = strings.TrimSpace(ref)
+	id, idErr := strconv.Atoi(ref)
+
+	for i := range mailboxes {
+		if idErr == nil && mailboxes[i].ID == id {
+			return &mailboxes[i]
+		}
+		if strings.EqualFold(mailboxes[i].Email, ref) {
+			return &mailboxes[i]
+		}
+	}
+
+	return nil
+}
+
+// printMailboxSummary shows what deleting a mailbox does to its aliases
+func printMailboxSummary(mailbox *simplelogin.Mailbox, affected []simplelogin.Alias, transferTo string) {
+	fmt.Fprintf(os.Stderr, "Mailbox: %s (ID %d)\n", mailbox.Email, mailbox.ID)
+
+	if len(affected) == 0 {
+		fmt.Fprintln(os.Stderr, "No alias is owned by this mailbox only.")
+		return
+	}
+
+	if transferTo != "" {
+		fmt.Fprintf(os.Stderr, "%d aliases are transferred to %s:\n", len(affected), transferTo)
+	} else {
+		fmt.Fprintf(os.Stderr, "%d aliases are deleted with it:\n", len(affected))
+	}
+
+	for _, alias := range affected {
+		fmt.Fprintf(os.Stderr, "  %s (%d forwarded)\n", alias.Email, alias.NbForward)
+	}
+}
+
 const deleteDescription = `
 Delete mailbox
 
+The aliases owned by this mailbox only are transferred to the mailbox given
+to --transfer-aliases-to, or deleted with it when --delete-aliases is given;
+one of them is required when the mailbox owns such aliases. They are listed
+and a confirmation asked first; pass --yes in scripts. The deletion is refused
+when it would delete an alias matched by the "protect" rules of the
+configuration (see 'simplelogin-cli alias delete --help').
 `
@@ -0,0 +1,103 @@
+package mcp
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/mcp"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mcp",
+		Short: "Model Context Protocol server",
+		Long:  mcpDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "serve",
+		Short: "Serve alias tools over MCP on stdio",
+		Long:  serveDescription,
+		Args:  cobra.NoArgs,
+		Run:   runServe,
+	})
+
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	// stdout carries the protocol, diagnostics go to stderr
+	log.SetOutput(os.Stderr)
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	version := "dev"
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		version = info.Main.Version
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	server := mcp.NewServer("simplelogin-cli", version, mcp.AliasTools(client))
+	if err := server.Serve(ctx, os.Stdin, os.Stdout); err != nil && ctx.Err() == nil {
+		log.Fatal(err)
+	}
+}
+
+const mcpDescription = `
+The **simplelogin-cli mcp** command serves SimpleLogin tools to assistants
+using the Model Context Protocol.
+
+To see help for a subcommand, use:
+
+    simplelogin-cli mcp [command] --help
+
+`
+
+const serveDescription = `
+Serve alias tools over MCP on stdio
+
+The assistant starts this command and talks to it over stdin/stdout; the API
+key stays in the CLI's storage and is never shown to the assistant.
+
+Tools: list_aliases, get_alias, create_random_alias, toggle_alias,
+get_alias_activities, list_alias_contacts and block_contact. Destructive
+tools (toggle_alias, block_contact) only run when called with
+"confirm": true, which assistants are told to set after asking the user.
+
+Example client configuration:
+
+    {
+        "mcpServers": {
+            "simplelogin": { "command": "simplelogin-cli", "args": ["mcp", "serve"] }
+        }
+    }
+
+`
@@ -0,0 +1,466 @@
+package migrate
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/migrate"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compact   bool
+	noHeaders bool
+)
+
+var (
+	migrateFromProfile string
+	migrateToProfile   string
+	migrateCheckpoint  string
+	migrateContacts    bool
+	migrateSettings    bool
+	migrateRate        int
+	migrateDryRun      bool
+)
+
+// signedSuffixTTL is how long signed suffixes are reused before fetching new ones
+const signedSuffixTTL = 5 * time.Minute
+
+func NewCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy aliases between accounts or instances",
+		Long:  migrateDescription,
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runMigrate(outputFormat)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+	flags.BoolVar(&noHeaders, "no-headers", false, "Hide table headers")
+
+	flags.StringVar(&migrateFromProfile, "from-profile", "", "Profile of the source account")
+	flags.StringVar(&migrateToProfile, "to-profile", "", "Profile of the destination account")
+	flags.StringVar(&migrateCheckpoint, "checkpoint", "", "Checkpoint file used to resume (default: ~/.config/simplelogin-cli/migrate-<from>-<to>.json)")
+	flags.BoolVar(&migrateContacts, "contacts", true, "Copy the contacts of each alias")
+	flags.BoolVar(&migrateSettings, "settings", true, "Copy the account settings")
+	flags.IntVar(&migrateRate, "rate", 5, "Maximum API requests per minute on the destination (0 for no limit)")
+	flags.BoolVar(&migrateDryRun, "dry-run", false, "Only report what would be migrated")
+
+	cmd.MarkFlagRequired("from-profile")
+	cmd.MarkFlagRequired("to-profile")
+
+	return cmd
+}
+
+func runMigrate(outputFormat *string) {
+	defer utils.RecoverFunc()
+
+	if migrateFromProfile == migrateToProfile {
+		log.Fatal("--from-profile and --to-profile must differ")
+	}
+
+	src, err := newProfileClient(migrateFromProfile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dst, err := newProfileClient(migrateToProfile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	dst.SetRateLimit(migrateRate, time.Minute)
+
+	path := migrateCheckpoint
+	if path == "" {
+		if path, err = defaultCheckpointPath(migrateFromProfile, migrateToProfile); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	cp, err := migrate.Load(path, migrateFromProfile, migrateToProfile)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if migrateSettings && !cp.Settings && !migrateDryRun {
+		if err := copySettings(src, dst); err != nil {
+			fmt.Fprintf(os.Stderr, "⚠️  Warning: settings not copied: %v\n", err)
+		} else {
+			cp.Settings = true
+			if err := cp.Save(); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	m, err := newMigrator(src, dst)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	aliases, err := src.GetAllAliases(simplelogin.AliasListOptions{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	results := make([]migrate.Result, 0, len(aliases))
+	failed := 0
+
+	for _, alias := range aliases {
+		if result, done := cp.Done(alias.Email); done {
+			results = append(results, result)
+			continue
+		}
+
+		result := m.migrateAlias(alias)
+		if result.Status == migrate.StatusFailed {
+			failed++
+		}
+		results = append(results, result)
+
+		if !migrateDryRun {
+			if err := cp.Record(result); err != nil {
+				log.Fatal(err)
+			}
+		}
+	}
+
+	switch *outputFormat {
+	case "json":
+		if err := display.DisplayData(results, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default: // table
+		if len(results) == 0 {
+			fmt.Println("No aliases found.")
+			return
+		}
+
+		tableOpts := display.DefaultTableOptions()
+		if noHeaders {
+			tableOpts.NoHeaders = true
+		}
+		if compact {
+			tableOpts = display.CompactTableOptions()
+		}
+
+		table := display.NewTable(tableOpts)
+		table.SetHeader([]string{"Source", "Destination", "Status", "Reason"})
+
+		for _, result := range results {
+			table.Append([]string{result.Source, result.Destination, result.Status, result.Reason})
+		}
+
+		table.Render()
+
+		if !migrateDryRun {
+			fmt.Printf("\nCheckpoint: %s\n", path)
+		}
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func newProfileClient(profile string) (*simplelogin.Client, error) {
+	cfg, err := config.LoadProfile(profile)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := config.LoadApiKeyProfile(profile)
+	if err != nil {
+		return nil, fmt.Errorf("profile %s: %w", profile, err)
+	}
+
+	return simplelogin.NewClient(cfg.ApiURL, apiKey)
+}
+
+func defaultCheckpointPath(from, to string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "simplelogin-cli", fmt.Sprintf("migrate-%s-%s.json", from, to)), nil
+}
+
+// copySettings copies the account settings, keeping the destination's
+// default random alias domain when the source one is not available there
+func copySettings(src, dst *simplelogin.Client) error {
+	setting, err := src.GetSetting()
+	if err != nil {
+		return err
+	}
+
+	current, err := dst.GetSetting()
+	if err != nil {
+		return err
+	}
+
+	domains, err := dst.GetSettingDomains()
+	if err != nil {
+		return err
+	}
+
+	available := false
+	for _, domain := range domains {
+		available = available || domain.Domain == setting.RandomAliasDefaultDomain
+	}
+	if !available {
+		setting.RandomAliasDefaultDomain = current.RandomAliasDefaultDomain
+	}
+
+	_, err = dst.UpdateSetting(*setting)
+	return err
+}
+
+// migrator recreates source aliases on the destination
+type migrator struct {
+	src, dst *simplelogin.Client
+
+	// customDomains holds the custom domains of the source account
+	customDomains map[string]bool
+	// mailboxes maps verified destination mailboxes by email
+	mailboxes      map[string]int
+	defaultMailbox int
+	// existing maps destination aliases by email
+	existing map[string]int
+
+	suffixes *simplelogin.SuffixResolver
+}
+
+func newMigrator(src, dst *simplelogin.Client) (*migrator, error) {
+	m := &migrator{
+		src:           src,
+		dst:           dst,
+		customDomains: map[string]bool{},
+		mailboxes:     map[string]int{},
+		existing:      map[string]int{},
+		suffixes:      simplelogin.NewSuffixResolver(dst, "", signedSuffixTTL),
+	}
+
+	domains, err := src.GetDomains()
+	if err != nil {
+		return nil, err
+	}
+	for _, domain := range domains {
+		m.customDomains[strings.ToLower(domain.DomainName)] = true
+	}
+
+	mailboxes, err := dst.GetMailboxes()
+	if err != nil {
+		return nil, err
+	}
+	for _, mailbox := range mailboxes {
+		if !mailbox.Verified {
+			continue
+		}
+		m.mailboxes[strings.ToLower(mailbox.Email)] = mailbox.ID
+		if mailbox.Default {
+			m.defaultMailbox = mailbox.ID
+		}
+	}
+
+	aliases, err := dst.GetAllAliases(simplelogin.AliasListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, alias := range aliases {
+		m.existing[strings.ToLower(alias.Email)] = alias.ID
+	}
+
+	return m, nil
+}
+
+// suffixFor returns the destination custom suffix matching a source alias
+func (m *migrator) suffixFor(email string) (string, *simplelogin.AliasOptionsSuffix, error) {
+	prefix, suffix, err := m.suffixes.Resolve(email, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	_, domain, _ := strings.Cut(strings.ToLower(email), "@")
+	if !suffix.IsCustom || suffix.Suffix != "@"+domain {
+		return "", nil, fmt.Errorf("no custom domain %s on the destination", domain)
+	}
+
+	return prefix, suffix, nil
+}
+
+func (m *migrator) migrateAlias(alias simplelogin.Alias) migrate.Result {
+	email := strings.ToLower(alias.Email)
+	result := migrate.Result{Source: alias.Email, Destination: alias.Email}
+
+	if id, ok := m.existing[email]; ok {
+		result.ID = id
+		result.Status = migrate.StatusExists
+		return result
+	}
+
+	_, domain, _ := strings.Cut(email, "@")
+	if !m.customDomains[domain] {
+		result.Destination = ""
+		result.Status = migrate.StatusUnmigratable
+		result.Reason = "SimpleLogin-owned suffix @" + domain
+		return result
+	}
+
+	if _, _, err := m.suffixFor(email); err != nil {
+		result.Destination = ""
+		result.Status = migrate.StatusUnmigratable
+		result.Reason = err.Error()
+		return result
+	}
+
+	mailboxIDs, missing := m.mapMailboxes(alias.Mailboxes)
+	if len(missing) > 0 {
+		result.Reason = "mailboxes not on the destination, using the default one: " + strings.Join(missing, ", ")
+	}
+
+	if migrateDryRun {
+		result.Status = migrate.StatusPlanned
+		return result
+	}
+
+	created, err := m.suffixes.Create(email, simplelogin.AliasCreateCustomOptions{
+		MailboxIDs: mailboxIDs,
+		Note:       alias.Note,
+		Name:       alias.Name,
+	})
+	if err != nil {
+		result.Status = migrate.StatusFailed
+		result.Reason = err.Error()
+		return result
+	}
+
+	result.ID = created.ID
+	result.Status = migrate.StatusMigrated
+	m.existing[email] = created.ID
+
+	var warnings []string
+	if result.Reason != "" {
+		warnings = append(warnings, result.Reason)
+	}
+	if err := m.copyState(alias, created.ID); err != nil {
+		warnings = append(warnings, err.Error())
+	}
+	if migrateContacts {
+		if err := m.copyContacts(alias.ID, created.ID); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	result.Reason = strings.Join(warnings, "; ")
+
+	return result
+}
+
+// mapMailboxes maps source mailboxes to destination mailboxes by email
+func (m *migrator) mapMailboxes(mailboxes []simplelogin.Mailbox) ([]int, []string) {
+	var ids []int
+	var missing []string
+
+	for _, mailbox := range mailboxes {
+		if id, ok := m.mailboxes[strings.ToLower(mailbox.Email)]; ok {
+			ids = append(ids, id)
+		} else {
+			missing = append(missing, mailbox.Email)
+		}
+	}
+
+	if len(ids) == 0 && m.defaultMailbox != 0 {
+		ids = []int{m.defaultMailbox}
+	}
+
+	return ids, missing
+}
+
+// copyState disables and pins the new alias like the source one
+func (m *migrator) copyState(alias simplelogin.Alias, aliasID int) error {
+	if alias.Pinned {
+		pinned := true
+		if err := m.dst.UpdateAlias(aliasID, simplelogin.AliasUpdateOptions{Pinned: &pinned}); err != nil {
+			return fmt.Errorf("not pinned: %w", err)
+		}
+	}
+
+	if !alias.Enabled {
+		if _, err := m.dst.ToggleAlias(aliasID); err != nil {
+			return fmt.Errorf("not disabled: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// copyContacts recreates the contacts of a source alias, blocked ones included
+func (m *migrator) copyContacts(srcAliasID, dstAliasID int) error {
+	contacts, err := m.src.GetAllAliasContacts(srcAliasID)
+	if err != nil {
+		return fmt.Errorf("contacts not copied: %w", err)
+	}
+
+	var failed []string
+	for _, contact := range contacts {
+		created, err := m.dst.CreateAliasContact(dstAliasID, contact.Contact)
+		if err != nil {
+			failed = append(failed, contact.Contact)
+			continue
+		}
+
+		if contact.BlockForward && !created.BlockForward {
+			if _, err := m.dst.ToggleContact(created.ID); err != nil {
+				failed = append(failed, contact.Contact)
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("contacts not copied: %s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+const migrateDescription = `
+Copy aliases between accounts or instances
+
+Both accounts are configured as profiles in the configuration file, e.g.
+~/.config/simplelogin-cli/config.json:
+
+    {
+      "api_url": "https://app.simplelogin.io/api",
+      "profiles": {
+        "selfhosted": { "api_url": "https://sl.example.com/api" }
+      }
+    }
+
+The API key of a profile is stored with 'simplelogin-cli --profile <name> auth set-key'.
+
+Aliases on custom domains present on both sides are recreated with their
+note, name, mailboxes (mapped by email), contacts and state. Aliases using
+SimpleLogin-owned suffixes, or custom domains missing on the destination,
+are reported as unmigratable. Aliases that already exist are skipped.
+
+Progress is saved to a checkpoint file after each alias: run the same
+command again to resume, failed aliases are retried.
+
+Example:
+
+    simplelogin-cli migrate --from-profile default --to-profile selfhosted --dry-run
+
+`
@@ -0,0 +1,39 @@
+package notification
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	compact   bool
+	noHeaders bool
+)
+
+func NewCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "notification",
+		Aliases: []string{"notif"},
+		Short:   "Manage notifications",
+		Long:    notificationDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+
+	cmd.AddCommand(
+		newListCommand(outputFormat),
+		newReadAllCommand(),
+		newReadCommand(),
+	)
+
+	return cmd
+}
+
+const notificationDescription = `
+The **simplelogin-cli notification** command has subcommands for managing notifications.
+
+To see help for a subcommand, use:
+
+    simplelogin-cli notification [command] --help
+
+`
@@ -0,0 +1,135 @@
+package notification
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	listPage   int
+	listUnread bool
+)
+
+func newListCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List notifications",
+		Long:    listDescription,
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runList(outputFormat, cmd.Flags().Changed("page"))
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+	flags.BoolVar(&noHeaders, "no-headers", false, "Hide table headers")
+
+	flags.IntVarP(&listPage, "page", "p", 0, "Only fetch this page (default: all pages)")
+	flags.BoolVarP(&listUnread, "unread", "u", false, "Only show unread notifications")
+
+	return cmd
+}
+
+func runList(outputFormat *string, singlePage bool) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var notifications []simplelogin.Notification
+	if singlePage {
+		result, err := client.GetNotifications(listPage)
+		if err != nil {
+			log.Fatal(err)
+		}
+		notifications = result.Notifications
+	} else {
+		notifications, err = client.GetAllNotifications()
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if listUnread {
+		notifications = filterUnread(notifications)
+	}
+
+	switch *outputFormat {
+	case "json":
+		if len(notifications) == 0 {
+			fmt.Printf("%v\n", notifications)
+			return
+		}
+
+		if err := display.DisplayData(notifications, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default: // table
+		if len(notifications) == 0 {
+			fmt.Println("No notifications found.")
+			return
+		}
+
+		tableOpts := display.DefaultTableOptions()
+		if noHeaders {
+			tableOpts.NoHeaders = true
+		}
+		if compact {
+			tableOpts = display.CompactTableOptions()
+		}
+
+		table := display.NewTable(tableOpts)
+		table.SetHeader([]string{"ID", "Read", "Created", "Title", "Message"})
+
+		for _, notification := range notifications {
+			table.Append([]string{
+				display.FormatID(notification.ID),
+				display.FormatBool(notification.Read),
+				display.FormatDate(notification.CreatedAt),
+				notification.Title,
+				display.FormatEmail(notification.Message, 60),
+			})
+		}
+
+		table.Render()
+		fmt.Printf("\nTotal: %d notifications\n", len(notifications))
+	}
+}
+
+func filterUnread(notifications []simplelogin.Notification) []simplelogin.Notification {
+	var unread []simplelogin.Notification
+	for _, notification := range notifications {
+		if !notification.Read {
+			unread = append(unread, notification)
+		}
+	}
+	return unread
+}
+
+const listDescription = `
+List notifications
+
+`
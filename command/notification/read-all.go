@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+func newReadAllCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "read-all",
+		Short: "Mark all notifications as read",
+		Long:  readAllDescription,
+		Args:  cobra.NoArgs,
+		Run:   runReadAll,
+	}
+
+	return cmd
+}
+
+func runReadAll(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	notifications, err := client.GetAllNotifications()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	count := 0
+	for _, notification := range filterUnread(notifications) {
+		if err := client.MarkNotificationRead(notification.ID); err != nil {
+			log.Fatalf("Failed to mark notification %d as read: %v", notification.ID, err)
+		}
+		count++
+	}
+
+	fmt.Printf("%d notifications marked as read\n", count)
+}
+
+const readAllDescription = `
+Mark all unread notifications as read
+
+`
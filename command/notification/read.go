@@ -0,0 +1,59 @@
+package notification
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+func newReadCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "read [notification_id]",
+		Short: "Mark a notification as read",
+		Long:  readDescription,
+		Args:  cobra.ExactArgs(1),
+		Run:   runRead,
+	}
+
+	return cmd
+}
+
+func runRead(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	notificationID, err := strconv.Atoi(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := client.MarkNotificationRead(notificationID); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Notification %d marked as read\n", notificationID)
+}
+
+const readDescription = `
+Mark a notification as read
+
+`
@@ -0,0 +1,212 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/server"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compact   bool
+	noHeaders bool
+)
+
+var (
+	serveListen    string
+	serveTokens    string
+	serveCacheTTL  time.Duration
+	serveRate      int
+	serveAccessLog string
+	serveRemote    bool
+)
+
+func NewCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a local REST API for alias operations",
+		Long:  serveDescription,
+		Args:  cobra.NoArgs,
+		Run:   runServe,
+	}
+
+	flags := cmd.PersistentFlags()
+	flags.StringVar(&serveTokens, "tokens", "", "Client token file (default: ~/.config/simplelogin-cli/serve-tokens-<profile>.json)")
+
+	flags = cmd.Flags()
+	flags.StringVar(&serveListen, "listen", "127.0.0.1:7777", "TCP address, or unix:<path> for a Unix socket")
+	flags.BoolVar(&serveRemote, "allow-remote", false, "Allow a non-loopback --listen address, served over plain HTTP")
+	flags.DurationVar(&serveCacheTTL, "cache-ttl", time.Minute, "How long the alias list is served from memory")
+	flags.IntVar(&serveRate, "rate", 60, "Maximum API requests per minute (0 for no limit)")
+	flags.StringVar(&serveAccessLog, "access-log", "-", "Access log file, '-' for stderr, empty to disable")
+
+	cmd.AddCommand(newTokenCommand(outputFormat))
+
+	return cmd
+}
+
+func runServe(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	if !serveRemote && !isLocal(serveListen) {
+		log.Fatalf("%s is reachable from the network over plain HTTP, listen on a loopback address or a Unix socket, or pass --allow-remote", serveListen)
+	}
+
+	store, err := loadTokens()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(store.Tokens) == 0 {
+		log.Fatal("no client token, create one with 'simplelogin-cli serve token add <name>'")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client.SetRateLimit(serveRate, time.Minute)
+
+	opts := server.Options{CacheTTL: serveCacheTTL}
+	switch serveAccessLog {
+	case "":
+	case "-":
+		opts.AccessLog = log.New(os.Stderr, "", log.LstdFlags)
+	default:
+		f, err := os.OpenFile(serveAccessLog, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		opts.AccessLog = log.New(f, "", log.LstdFlags)
+	}
+
+	listener, err := listen(serveListen)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	httpServer := &http.Server{
+		Handler:           server.New(client, store, opts).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		httpServer.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Listening on %s\n", serveListen)
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
+}
+
+// listen opens a TCP listener, or a Unix socket only its owner can use
+func listen(address string) (net.Listener, error) {
+	path, ok := strings.CutPrefix(address, "unix:")
+	if !ok {
+		return net.Listen("tcp", address)
+	}
+
+	// Remove a socket left behind by a previous run
+	if info, err := os.Stat(path); err == nil && info.Mode()&os.ModeSocket != 0 {
+		os.Remove(path)
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+// isLocal reports whether a --listen address is a Unix socket or a loopback TCP address
+func isLocal(address string) bool {
+	if strings.HasPrefix(address, "unix:") {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func loadTokens() (*server.TokenStore, error) {
+	path := serveTokens
+	if path == "" {
+		var err error
+		if path, err = server.TokensPath(config.ActiveProfile()); err != nil {
+			return nil, err
+		}
+	}
+	return server.LoadTokens(path)
+}
+
+const serveDescription = `
+Serve a local REST API for alias operations
+
+Local tools such as browser automation and scripts call this API with their
+own client token instead of each handling the SimpleLogin API key. Requests
+share one API client, an in-memory alias cache and a rate limiter, and are
+recorded in the access log.
+
+The API is served over plain HTTP on a loopback address or a Unix socket;
+other addresses are refused unless --allow-remote is given. Tokens added or
+removed with 'serve token' take effect without restarting the daemon.
+
+Endpoints (JSON, "Authorization: Bearer <token>" except /v1/health):
+
+    GET  /v1/health
+    GET  /v1/aliases?query=<text>&site=<url>   search aliases
+    POST /v1/aliases {"hostname": "...", "mode": "", "note": "", "new": false}
+                                               alias for a website, created when needed
+    POST /v1/aliases/<id>/toggle               enable or disable an alias
+
+Example:
+
+    simplelogin-cli serve token add browser
+    simplelogin-cli serve --listen unix:/run/user/1000/simplelogin.sock
+
+`
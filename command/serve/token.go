@@ -0,0 +1,150 @@
+package serve
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/spf13/cobra"
+)
+
+func newTokenCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage client tokens",
+		Long:  tokenDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Aliases: []string{"ls"},
+		Short:   "List client tokens",
+		Args:    cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runTokenList(outputFormat)
+		},
+	}
+	listCmd.Flags().BoolVar(&compact, "compact", false, "Compact output")
+	listCmd.Flags().BoolVar(&noHeaders, "no-headers", false, "Hide table headers")
+
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "add [name]",
+			Short: "Create a client token",
+			Args:  cobra.ExactArgs(1),
+			Run:   runTokenAdd,
+		},
+		listCmd,
+		&cobra.Command{
+			Use:     "remove [name]",
+			Aliases: []string{"rm"},
+			Short:   "Revoke a client token",
+			Args:    cobra.ExactArgs(1),
+			Run:     runTokenRemove,
+		},
+	)
+
+	return cmd
+}
+
+func runTokenAdd(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	store, err := loadTokens()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	token, err := store.Add(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := store.Save(); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(token)
+}
+
+func runTokenRemove(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	store, err := loadTokens()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := store.Remove(args[0]); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := store.Save(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func runTokenList(outputFormat *string) {
+	defer utils.RecoverFunc()
+
+	store, err := loadTokens()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch *outputFormat {
+	case "json":
+		type tokenInfo struct {
+			Name      string    `json:"name"`
+			CreatedAt time.Time `json:"created_at"`
+		}
+
+		result := make([]tokenInfo, len(store.Tokens))
+		for i, token := range store.Tokens {
+			result[i] = tokenInfo{Name: token.Name, CreatedAt: token.CreatedAt}
+		}
+
+		if err := display.DisplayData(result, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default: // table
+		if len(store.Tokens) == 0 {
+			fmt.Println("No tokens found.")
+			return
+		}
+
+		tableOpts := display.DefaultTableOptions()
+		if noHeaders {
+			tableOpts.NoHeaders = true
+		}
+		if compact {
+			tableOpts = display.CompactTableOptions()
+		}
+
+		table := display.NewTable(tableOpts)
+		table.SetHeader([]string{"Name", "Created"})
+
+		for _, token := range store.Tokens {
+			table.Append([]string{token.Name, token.CreatedAt.Local().Format(time.DateTime)})
+		}
+
+		table.Render()
+	}
+}
+
+const tokenDescription = `
+Manage the client tokens accepted by 'simplelogin-cli serve'
+
+Each local tool gets its own token so that it can be revoked on its own and
+identified in the access log. Tokens are printed once when created; only
+their hash is stored.
+
+`
@@ -0,0 +1,38 @@
+package snapshot
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	compact   bool
+	noHeaders bool
+)
+
+func NewCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Take and compare account snapshots",
+		Long:  snapshotDescription,
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Usage()
+		},
+	}
+
+	cmd.AddCommand(
+		newDiffCommand(outputFormat),
+		newTakeCommand(),
+	)
+
+	return cmd
+}
+
+const snapshotDescription = `
+The **simplelogin-cli snapshot** command has subcommands for taking account
+snapshots and comparing them, to notice unexpected changes between audits.
+
+To see help for a subcommand, use:
+
+    simplelogin-cli snapshot [command] --help
+
+`
@@ -0,0 +1,173 @@
+package snapshot
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/snapshot"
+	"github.com/juli3nk/simplelogin-cli/internal/tags"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffNoContacts bool
+	diffTags       []string
+	diffExitCode   bool
+)
+
+func newDiffCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff [snapshot] [snapshot]",
+		Short: "Show the changes between two snapshots, or a snapshot and the live account",
+		Long:  diffDescription,
+		Args:  cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			runDiff(outputFormat, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+	flags.BoolVar(&noHeaders, "no-headers", false, "Hide table headers")
+
+	flags.BoolVar(&diffNoContacts, "no-contacts", false, "Skip alias contacts when reading the live account")
+	flags.StringSliceVar(&diffTags, "tag", []string{}, "Only aliases with this tag when reading the live account (repeatable)")
+	flags.BoolVar(&diffExitCode, "exit-code", false, "Exit with status 1 when there are changes")
+
+	return cmd
+}
+
+func runDiff(outputFormat *string, args []string) {
+	defer utils.RecoverFunc()
+
+	a, err := loadSnapshot(args[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var b *snapshot.Snapshot
+	if len(args) == 2 {
+		if b, err = loadSnapshot(args[1]); err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		if b, err = takeLive(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	changes := snapshot.Diff(a, b)
+
+	switch *outputFormat {
+	case "json":
+		if changes == nil {
+			changes = []snapshot.Change{}
+		}
+		if err := display.DisplayData(changes, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default: // table
+		if len(changes) == 0 {
+			fmt.Println("No changes.")
+			return
+		}
+
+		tableOpts := display.DefaultTableOptions()
+		if noHeaders {
+			tableOpts.NoHeaders = true
+		}
+		if compact {
+			tableOpts = display.CompactTableOptions()
+		}
+
+		table := display.NewTable(tableOpts)
+		table.SetHeader([]string{"Change", "Object", "Subject", "Field", "Old", "New"})
+
+		for _, change := range changes {
+			table.Append([]string{change.Kind, change.Object, change.Subject, change.Field, change.Old, change.New})
+		}
+
+		table.Render()
+		fmt.Printf("\nTotal: %d changes\n", len(changes))
+	}
+
+	if diffExitCode && len(changes) > 0 {
+		os.Exit(1)
+	}
+}
+
+// loadSnapshot loads a snapshot file, "latest" being the newest snapshot of
+// the active profile in the default directory
+func loadSnapshot(ref string) (*snapshot.Snapshot, error) {
+	if ref != "latest" {
+		return snapshot.Load(ref)
+	}
+
+	dir, err := defaultDir()
+	if err != nil {
+		return nil, err
+	}
+
+	// Timestamps in the names sort chronologically
+	paths, err := filepath.Glob(filepath.Join(dir, config.ActiveProfile()+"-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no snapshot found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	return snapshot.Load(paths[len(paths)-1])
+}
+
+func takeLive() (*snapshot.Snapshot, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := snapshot.Options{Contacts: !diffNoContacts}
+	if len(diffTags) > 0 {
+		opts.Filter = func(alias simplelogin.Alias) bool {
+			return tags.HasAll(alias.Note, diffTags)
+		}
+	}
+
+	return snapshot.Take(client, opts)
+}
+
+const diffDescription = `
+Show the changes between two snapshots, or between a snapshot and the live
+account when only one is given
+
+Reported changes include added and removed aliases, mailboxes and domains,
+enabled/disabled aliases, changed notes and names, mailbox reassignments,
+blocked contacts and settings. "latest" refers to the newest snapshot of the
+active profile.
+
+Example:
+
+    simplelogin-cli snapshot diff latest --exit-code
+
+`
@@ -0,0 +1,115 @@
+package snapshot
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/snapshot"
+	"github.com/juli3nk/simplelogin-cli/internal/tags"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	takeOut        string
+	takeDir        string
+	takeNoContacts bool
+	takeTags       []string
+)
+
+func newTakeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "take",
+		Short: "Save a snapshot of the account",
+		Long:  takeDescription,
+		Args:  cobra.NoArgs,
+		Run:   runTake,
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&takeOut, "out", "", "Snapshot file, '-' for stdout (default: a timestamped file in --dir)")
+	flags.StringVar(&takeDir, "dir", "", "Snapshot directory (default: ~/.config/simplelogin-cli/snapshots)")
+	flags.BoolVar(&takeNoContacts, "no-contacts", false, "Skip alias contacts, one request per alias")
+	flags.StringSliceVar(&takeTags, "tag", []string{}, "Only aliases with this tag (repeatable)")
+
+	return cmd
+}
+
+func runTake(cmd *cobra.Command, args []string) {
+	defer utils.RecoverFunc()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := snapshot.Options{Contacts: !takeNoContacts}
+	if len(takeTags) > 0 {
+		opts.Filter = func(alias simplelogin.Alias) bool {
+			return tags.HasAll(alias.Note, takeTags)
+		}
+	}
+
+	snap, err := snapshot.Take(client, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if takeOut == "-" {
+		if err := snap.Write(os.Stdout); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	path := takeOut
+	if path == "" {
+		dir := takeDir
+		if dir == "" {
+			if dir, err = defaultDir(); err != nil {
+				log.Fatal(err)
+			}
+		}
+		path = filepath.Join(dir, fmt.Sprintf("%s-%s.json", config.ActiveProfile(), snap.TakenAt.Format("20060102T150405Z")))
+	}
+
+	if err := snap.Save(path); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(path)
+}
+
+func defaultDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "simplelogin-cli", "snapshots"), nil
+}
+
+const takeDescription = `
+Save a snapshot of the account
+
+The snapshot holds aliases (with their contacts), mailboxes, domains and
+settings as normalised JSON: counters and activity are left out and lists
+are sorted, so that snapshots only differ when the account changed.
+
+The file is written to ~/.config/simplelogin-cli/snapshots/<profile>-<time>.json
+by default and its path is printed.
+
+`
@@ -0,0 +1,90 @@
+package tui
+
+import (
+	"log"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/completion"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/prompt"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	refreshInterval time.Duration
+	query           string
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "tui",
+		Short:             "Browse and manage aliases in a full-screen terminal UI",
+		Long:              tuiDescription,
+		Args:              cobra.NoArgs,
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run: func(cmd *cobra.Command, args []string) {
+			runTUI()
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.DurationVar(&refreshInterval, "refresh", 30*time.Second, "Background refresh interval (0 disables it)")
+	flags.StringVarP(&query, "query", "q", "", "Only load aliases matching this search")
+
+	cmd.RegisterFlagCompletionFunc("query", completion.AliasEmails)
+
+	return cmd
+}
+
+func runTUI() {
+	defer utils.RecoverFunc()
+
+	if !prompt.IsTerminal() {
+		log.Fatal("tui requires a terminal")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	program := tea.NewProgram(newModel(client, query, refreshInterval), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+const tuiDescription = `Browse and manage aliases in a full-screen terminal UI.
+
+Aliases are loaded page by page while scrolling and refreshed in the
+background. The right pane shows the selected alias: mailboxes, note,
+latest activity and contacts.
+
+Keys:
+  up/down, j/k    Move the selection (pgup/pgdown, g/G to jump)
+  /               Filter loaded aliases, enter searches the server
+  esc             Clear the filter
+  t               Enable or disable the alias
+  p               Pin or unpin the alias
+  n               Edit the note in $VISUAL or $EDITOR
+  y               Copy the alias address to the clipboard
+  c               Select contacts, then b to block or unblock one
+  r               Refresh now
+  q               Quit
+
+Examples:
+  simplelogin-cli tui
+  simplelogin-cli tui --query shop --refresh 1m`
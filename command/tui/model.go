@@ -0,0 +1,685 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/juli3nk/simplelogin-cli/internal/journal"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+type mode int
+
+const (
+	modeBrowse mode = iota
+	modeFilter
+	modeContacts
+)
+
+// Messages returned by the commands running in the background. Those tied to
+// a list carry its generation so that results of a replaced list are dropped.
+type (
+	pageMsg struct {
+		gen     int
+		page    int
+		aliases []simplelogin.Alias
+		err     error
+	}
+
+	refreshMsg struct {
+		gen     int
+		aliases []simplelogin.Alias
+		err     error
+	}
+
+	tickMsg struct{}
+
+	contactsMsg struct {
+		aliasID  int
+		contacts []simplelogin.AliasContact
+		err      error
+	}
+
+	aliasMsg struct {
+		aliasID int
+		apply   func(*simplelogin.Alias)
+		status  string
+		err     error
+	}
+
+	contactMsg struct {
+		aliasID   int
+		contactID int
+		blocked   bool
+		warning   string
+		err       error
+	}
+
+	noteMsg struct {
+		aliasID int
+		email   string
+		before  string
+		note    string
+		err     error
+	}
+
+	statusMsg struct {
+		status string
+		err    error
+	}
+)
+
+type model struct {
+	client  *simplelogin.Client
+	refresh time.Duration
+
+	// The loaded list: aliases matching query, fetched up to nextPage
+	gen      int
+	query    string
+	aliases  []simplelogin.Alias
+	nextPage int
+	done     bool
+	loading  bool
+
+	mode   mode
+	filter string
+	cursor int
+	offset int
+
+	contacts        map[int][]simplelogin.AliasContact
+	contactsPending map[int]bool
+	contactCursor   int
+
+	status string
+	err    error
+
+	width  int
+	height int
+}
+
+func newModel(client *simplelogin.Client, query string, refresh time.Duration) *model {
+	return &model{
+		client:          client,
+		refresh:         refresh,
+		query:           query,
+		filter:          query,
+		contacts:        make(map[int][]simplelogin.AliasContact),
+		contactsPending: make(map[int]bool),
+	}
+}
+
+func (m *model) Init() tea.Cmd {
+	m.loading = true
+	return tea.Batch(m.loadPage(), m.tick())
+}
+
+func (m *model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.scroll()
+		return m, m.loadMore()
+
+	case tea.KeyMsg:
+		return m, m.handleKey(msg)
+
+	case pageMsg:
+		if msg.gen != m.gen || msg.page != m.nextPage {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		if len(msg.aliases) == 0 {
+			m.done = true
+		}
+		m.aliases = append(m.aliases, msg.aliases...)
+		m.nextPage++
+		return m, tea.Batch(m.loadMore(), m.loadContacts())
+
+	case refreshMsg:
+		if msg.gen != m.gen {
+			return m, nil
+		}
+		m.loading = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		selected := m.selectedID()
+		m.aliases = msg.aliases
+		m.err = nil
+		m.selectID(selected)
+
+		// Contacts may have changed too: fetch those of the selection again
+		clear(m.contacts)
+		clear(m.contactsPending)
+		return m, m.loadContacts()
+
+	case tickMsg:
+		if m.loading || m.mode == modeFilter {
+			return m, m.tick()
+		}
+		m.loading = true
+		return m, tea.Batch(m.reload(), m.tick())
+
+	case contactsMsg:
+		delete(m.contactsPending, msg.aliasID)
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.contacts[msg.aliasID] = msg.contacts
+		return m, nil
+
+	case aliasMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.updateAlias(msg.aliasID, msg.apply)
+		m.setStatus(msg.status)
+		return m, nil
+
+	case contactMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		for i, contact := range m.contacts[msg.aliasID] {
+			if contact.ID == msg.contactID {
+				m.contacts[msg.aliasID][i].BlockForward = msg.blocked
+				if msg.blocked {
+					m.setStatus("Blocked " + contact.Contact + msg.warning)
+				} else {
+					m.setStatus("Unblocked " + contact.Contact + msg.warning)
+				}
+			}
+		}
+		return m, nil
+
+	case noteMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		return m, m.updateNote(msg)
+
+	case statusMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, nil
+		}
+		m.setStatus(msg.status)
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m *model) handleKey(msg tea.KeyMsg) tea.Cmd {
+	if msg.Type == tea.KeyCtrlC {
+		return tea.Quit
+	}
+
+	switch m.mode {
+	case modeFilter:
+		return m.handleFilterKey(msg)
+	case modeContacts:
+		return m.handleContactsKey(msg)
+	}
+
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup":
+		m.move(-m.listHeight())
+	case "pgdown":
+		m.move(m.listHeight())
+	case "g", "home":
+		m.move(-len(m.aliases))
+	case "G", "end":
+		m.move(len(m.aliases))
+	case "/":
+		m.mode = modeFilter
+		return nil
+	case "esc":
+		return m.clearFilter()
+	case "r":
+		if m.loading {
+			return nil
+		}
+		m.loading = true
+		m.setStatus("Refreshing")
+		return m.reload()
+	case "t":
+		return m.toggle()
+	case "p":
+		return m.pin()
+	case "n":
+		return m.editNote()
+	case "y":
+		return m.copy()
+	case "c", "tab":
+		if alias := m.selected(); alias != nil && len(m.contacts[alias.ID]) > 0 {
+			m.mode = modeContacts
+			m.contactCursor = 0
+		}
+		return nil
+	default:
+		return nil
+	}
+
+	return tea.Batch(m.loadMore(), m.loadContacts())
+}
+
+func (m *model) handleFilterKey(msg tea.KeyMsg) tea.Cmd {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.mode = modeBrowse
+		return m.clearFilter()
+	case tea.KeyEnter:
+		// Search the server so that aliases not loaded yet match too
+		m.mode = modeBrowse
+		return m.reset(m.filter)
+	case tea.KeyBackspace:
+		if runes := []rune(m.filter); len(runes) > 0 {
+			m.filter = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.filter += string(msg.Runes)
+	default:
+		return nil
+	}
+
+	m.cursor, m.offset = 0, 0
+	return tea.Batch(m.loadMore(), m.loadContacts())
+}
+
+func (m *model) handleContactsKey(msg tea.KeyMsg) tea.Cmd {
+	alias := m.selected()
+	if alias == nil {
+		m.mode = modeBrowse
+		return nil
+	}
+	contacts := m.contacts[alias.ID]
+
+	switch msg.String() {
+	case "q":
+		return tea.Quit
+	case "esc", "c", "tab":
+		m.mode = modeBrowse
+	case "up", "k":
+		m.contactCursor = max(m.contactCursor-1, 0)
+	case "down", "j":
+		m.contactCursor = min(m.contactCursor+1, len(contacts)-1)
+	case "b":
+		if m.contactCursor < len(contacts) {
+			return m.blockContact(alias.ID, contacts[m.contactCursor])
+		}
+	}
+
+	return nil
+}
+
+// visible returns the loaded aliases matching the filter
+func (m *model) visible() []simplelogin.Alias {
+	filter := strings.ToLower(strings.TrimSpace(m.filter))
+	if filter == "" || filter == strings.ToLower(m.query) {
+		return m.aliases
+	}
+
+	var aliases []simplelogin.Alias
+	for _, alias := range m.aliases {
+		if strings.Contains(strings.ToLower(alias.Email), filter) ||
+			strings.Contains(strings.ToLower(alias.Name), filter) ||
+			strings.Contains(strings.ToLower(alias.Note), filter) {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+func (m *model) selected() *simplelogin.Alias {
+	aliases := m.visible()
+	if m.cursor < 0 || m.cursor >= len(aliases) {
+		return nil
+	}
+	return &aliases[m.cursor]
+}
+
+func (m *model) selectedID() int {
+	if alias := m.selected(); alias != nil {
+		return alias.ID
+	}
+	return 0
+}
+
+// selectID moves the cursor back to an alias after the list was replaced
+func (m *model) selectID(id int) {
+	aliases := m.visible()
+	if i := slices.IndexFunc(aliases, func(a simplelogin.Alias) bool { return a.ID == id }); i >= 0 {
+		m.cursor = i
+	}
+	m.cursor = max(min(m.cursor, len(aliases)-1), 0)
+	m.scroll()
+}
+
+func (m *model) move(delta int) {
+	previous := m.selectedID()
+
+	m.cursor = max(min(m.cursor+delta, len(m.visible())-1), 0)
+	m.scroll()
+
+	if m.selectedID() != previous {
+		m.contactCursor = 0
+	}
+}
+
+// scroll keeps the cursor inside the list viewport
+func (m *model) scroll() {
+	height := m.listHeight()
+	if m.cursor < m.offset {
+		m.offset = m.cursor
+	}
+	if m.cursor >= m.offset+height {
+		m.offset = m.cursor - height + 1
+	}
+	m.offset = max(m.offset, 0)
+}
+
+func (m *model) updateAlias(id int, apply func(*simplelogin.Alias)) {
+	for i := range m.aliases {
+		if m.aliases[i].ID == id {
+			apply(&m.aliases[i])
+		}
+	}
+}
+
+func (m *model) setStatus(status string) {
+	m.status = status
+	m.err = nil
+}
+
+func (m *model) clearFilter() tea.Cmd {
+	m.filter = ""
+	m.cursor, m.offset = 0, 0
+	if m.query != "" {
+		return m.reset("")
+	}
+	return tea.Batch(m.loadMore(), m.loadContacts())
+}
+
+// reset replaces the loaded list with the aliases matching query
+func (m *model) reset(query string) tea.Cmd {
+	m.gen++
+	m.query = query
+	m.aliases = nil
+	m.nextPage = 0
+	m.done = false
+	m.cursor, m.offset = 0, 0
+	m.loading = true
+	return m.loadPage()
+}
+
+// loadMore fetches the next page when the cursor gets close to the end of the list
+func (m *model) loadMore() tea.Cmd {
+	if m.done || m.loading || m.cursor < len(m.visible())-m.listHeight() {
+		return nil
+	}
+	m.loading = true
+	return m.loadPage()
+}
+
+func (m *model) loadPage() tea.Cmd {
+	client, gen, page, query := m.client, m.gen, m.nextPage, m.query
+
+	return func() tea.Msg {
+		aliases, err := client.GetAliases(simplelogin.AliasListOptions{Query: query}, page)
+		return pageMsg{gen: gen, page: page, aliases: aliases, err: err}
+	}
+}
+
+// reload fetches again every page loaded so far
+func (m *model) reload() tea.Cmd {
+	client, gen, pages, query := m.client, m.gen, max(m.nextPage, 1), m.query
+
+	return func() tea.Msg {
+		var aliases []simplelogin.Alias
+		for page := range pages {
+			result, err := client.GetAliases(simplelogin.AliasListOptions{Query: query}, page)
+			if err != nil {
+				return refreshMsg{gen: gen, err: err}
+			}
+			aliases = append(aliases, result...)
+		}
+		return refreshMsg{gen: gen, aliases: aliases}
+	}
+}
+
+func (m *model) tick() tea.Cmd {
+	if m.refresh <= 0 {
+		return nil
+	}
+	return tea.Tick(m.refresh, func(time.Time) tea.Msg {
+		return tickMsg{}
+	})
+}
+
+// loadContacts fetches the first page of contacts of the selected alias
+func (m *model) loadContacts() tea.Cmd {
+	alias := m.selected()
+	if alias == nil {
+		return nil
+	}
+	if _, ok := m.contacts[alias.ID]; ok || m.contactsPending[alias.ID] {
+		return nil
+	}
+	m.contactsPending[alias.ID] = true
+
+	client, id := m.client, alias.ID
+	return func() tea.Msg {
+		contacts, err := client.GetAliasContacts(id, 0)
+		return contactsMsg{aliasID: id, contacts: contacts, err: err}
+	}
+}
+
+func (m *model) toggle() tea.Cmd {
+	alias := m.selected()
+	if alias == nil {
+		return nil
+	}
+
+	client, id, email := m.client, alias.ID, alias.Email
+	return func() tea.Msg {
+		resp, err := client.ToggleAlias(id)
+		if err != nil {
+			return aliasMsg{err: err}
+		}
+
+		status := "Disabled " + email
+		if resp.Enabled {
+			status = "Enabled " + email
+		}
+
+		wasEnabled := !resp.Enabled
+		status += recordChange(journal.Entry{
+			Action:  journal.ActionAliasToggle,
+			AliasID: id,
+			Subject: email,
+			Before:  journal.State{Enabled: &wasEnabled},
+		})
+
+		return aliasMsg{
+			aliasID: id,
+			apply:   func(a *simplelogin.Alias) { a.Enabled = resp.Enabled },
+			status:  status,
+		}
+	}
+}
+
+func (m *model) pin() tea.Cmd {
+	alias := m.selected()
+	if alias == nil {
+		return nil
+	}
+
+	client, id, email, pinned := m.client, alias.ID, alias.Email, !alias.Pinned
+	return func() tea.Msg {
+		if err := client.UpdateAlias(id, simplelogin.AliasUpdateOptions{Pinned: &pinned}); err != nil {
+			return aliasMsg{err: err}
+		}
+
+		status := "Unpinned " + email
+		if pinned {
+			status = "Pinned " + email
+		}
+
+		wasPinned := !pinned
+		status += recordChange(journal.Entry{
+			Action:  journal.ActionAliasUpdate,
+			AliasID: id,
+			Subject: email,
+			Before:  journal.State{Pinned: &wasPinned},
+		})
+
+		return aliasMsg{
+			aliasID: id,
+			apply:   func(a *simplelogin.Alias) { a.Pinned = pinned },
+			status:  status,
+		}
+	}
+}
+
+// editNote opens the note of the selected alias in the user's editor
+func (m *model) editNote() tea.Cmd {
+	alias := m.selected()
+	if alias == nil {
+		return nil
+	}
+
+	f, err := os.CreateTemp("", "simplelogin-note-*.txt")
+	if err != nil {
+		m.err = err
+		return nil
+	}
+	path := f.Name()
+	_, err = f.WriteString(alias.Note)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		m.err = err
+		return nil
+	}
+
+	editor := strings.Fields(editorCommand())
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+
+	id, email, original := alias.ID, alias.Email, alias.Note
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path)
+
+		if err != nil {
+			return noteMsg{err: fmt.Errorf("editor: %w", err)}
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return noteMsg{err: err}
+		}
+
+		note := strings.TrimRight(string(data), "\n")
+		if note == original {
+			return statusMsg{status: "Note unchanged"}
+		}
+		return noteMsg{aliasID: id, email: email, before: original, note: note}
+	})
+}
+
+func (m *model) updateNote(msg noteMsg) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		if err := client.UpdateAlias(msg.aliasID, simplelogin.AliasUpdateOptions{Note: &msg.note}); err != nil {
+			return aliasMsg{err: err}
+		}
+
+		status := "Note updated" + recordChange(journal.Entry{
+			Action:  journal.ActionAliasUpdate,
+			AliasID: msg.aliasID,
+			Subject: msg.email,
+			Before:  journal.State{Note: &msg.before},
+		})
+
+		return aliasMsg{
+			aliasID: msg.aliasID,
+			apply:   func(a *simplelogin.Alias) { a.Note = msg.note },
+			status:  status,
+		}
+	}
+}
+
+func (m *model) copy() tea.Cmd {
+	alias := m.selected()
+	if alias == nil {
+		return nil
+	}
+
+	email := alias.Email
+	return func() tea.Msg {
+		if err := copyToClipboard(email); err != nil {
+			return statusMsg{err: err}
+		}
+		return statusMsg{status: "Copied " + email}
+	}
+}
+
+// blockContact blocks or unblocks a contact; the API only toggles
+func (m *model) blockContact(aliasID int, contact simplelogin.AliasContact) tea.Cmd {
+	client := m.client
+	return func() tea.Msg {
+		resp, err := client.ToggleContact(contact.ID)
+		if err != nil {
+			return contactMsg{err: err}
+		}
+		wasBlocked := !resp.BlockForward
+		warning := recordChange(journal.Entry{
+			Action:    journal.ActionContactBlock,
+			ContactID: contact.ID,
+			Subject:   contact.Contact,
+			Before:    journal.State{Blocked: &wasBlocked},
+		})
+
+		return contactMsg{aliasID: aliasID, contactID: contact.ID, blocked: resp.BlockForward, warning: warning}
+	}
+}
+
+// recordChange saves the state before a change for 'undo' and returns a
+// warning to append to the status line when it fails
+func recordChange(entry journal.Entry) string {
+	if warning := journal.RecordChange(entry); warning != "" {
+		return " (" + warning + ")"
+	}
+	return ""
+}
+
+func editorCommand() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if editor := strings.TrimSpace(os.Getenv(env)); editor != "" {
+			return editor
+		}
+	}
+	return "vi"
+}
@@ -0,0 +1,214 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+func testModel(t *testing.T, query string) *model {
+	t.Helper()
+
+	m := newModel(nil, query, 0)
+	m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	return m
+}
+
+func aliases(ids ...int) []simplelogin.Alias {
+	result := make([]simplelogin.Alias, len(ids))
+	for i, id := range ids {
+		result[i] = simplelogin.Alias{ID: id, Email: "alias" + string(rune('a'+id)) + "@example.com", Enabled: true}
+	}
+	return result
+}
+
+func ids(aliases []simplelogin.Alias) []int {
+	result := make([]int, len(aliases))
+	for i, alias := range aliases {
+		result[i] = alias.ID
+	}
+	return result
+}
+
+func key(s string) tea.KeyMsg {
+	switch s {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	default:
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+	}
+}
+
+func TestUpdate_Pages(t *testing.T) {
+	tests := []struct {
+		name     string
+		msgs     []tea.Msg
+		want     []int
+		nextPage int
+		done     bool
+	}{
+		{
+			name:     "pages in order",
+			msgs:     []tea.Msg{pageMsg{page: 0, aliases: aliases(1, 2)}, pageMsg{page: 1, aliases: aliases(3)}},
+			want:     []int{1, 2, 3},
+			nextPage: 2,
+		},
+		{
+			name:     "empty page ends the list",
+			msgs:     []tea.Msg{pageMsg{page: 0, aliases: aliases(1)}, pageMsg{page: 1}},
+			want:     []int{1},
+			nextPage: 2,
+			done:     true,
+		},
+		{
+			name:     "stale generation dropped",
+			msgs:     []tea.Msg{pageMsg{gen: -1, page: 0, aliases: aliases(9)}, pageMsg{page: 0, aliases: aliases(1)}},
+			want:     []int{1},
+			nextPage: 1,
+		},
+		{
+			name:     "duplicate page dropped",
+			msgs:     []tea.Msg{pageMsg{page: 0, aliases: aliases(1)}, pageMsg{page: 0, aliases: aliases(1)}},
+			want:     []int{1},
+			nextPage: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testModel(t, "")
+			for _, msg := range tt.msgs {
+				m.Update(msg)
+			}
+
+			if got := ids(m.aliases); !slices.Equal(got, tt.want) {
+				t.Errorf("aliases = %v, want %v", got, tt.want)
+			}
+			if m.nextPage != tt.nextPage || m.done != tt.done {
+				t.Errorf("nextPage, done = %d, %v, want %d, %v", m.nextPage, m.done, tt.nextPage, tt.done)
+			}
+		})
+	}
+}
+
+func TestUpdate_Refresh(t *testing.T) {
+	tests := []struct {
+		name    string
+		refresh refreshMsg
+		want    []int
+		// selected is the alias under the cursor after the refresh
+		selected int
+	}{
+		{
+			name:     "selection follows the alias",
+			refresh:  refreshMsg{aliases: aliases(5, 1, 2, 3)},
+			want:     []int{5, 1, 2, 3},
+			selected: 2,
+		},
+		{
+			name:     "selected alias removed",
+			refresh:  refreshMsg{aliases: aliases(1)},
+			want:     []int{1},
+			selected: 1,
+		},
+		{
+			name:     "stale generation dropped",
+			refresh:  refreshMsg{gen: -1, aliases: aliases(7)},
+			want:     []int{1, 2, 3},
+			selected: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testModel(t, "")
+			m.Update(pageMsg{page: 0, aliases: aliases(1, 2, 3)})
+			m.Update(key("j"))
+			m.contacts[2] = []simplelogin.AliasContact{{ID: 10, Contact: "shop@example.net"}}
+
+			m.Update(tt.refresh)
+
+			if got := ids(m.aliases); !slices.Equal(got, tt.want) {
+				t.Errorf("aliases = %v, want %v", got, tt.want)
+			}
+			if got := m.selectedID(); got != tt.selected {
+				t.Errorf("selected = %d, want %d", got, tt.selected)
+			}
+
+			_, cached := m.contacts[2]
+			if stale := tt.refresh.gen != m.gen; cached != stale {
+				t.Errorf("contacts cached = %v after refresh, want %v", cached, stale)
+			}
+		})
+	}
+}
+
+func TestUpdate_Filter(t *testing.T) {
+	m := testModel(t, "")
+	m.Update(pageMsg{page: 0, aliases: []simplelogin.Alias{
+		{ID: 1, Email: "shop@example.com"},
+		{ID: 2, Email: "news@example.com"},
+		{ID: 3, Email: "bank@example.com", Note: "shopping card"},
+	}})
+
+	for _, k := range []string{"/", "s", "h", "o", "p"} {
+		m.Update(key(k))
+	}
+	if m.mode != modeFilter || m.filter != "shop" {
+		t.Fatalf("mode, filter = %v, %q, want filter mode and shop", m.mode, m.filter)
+	}
+	if got := ids(m.visible()); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("visible = %v, want [1 3]", got)
+	}
+
+	// Enter searches the server: the list is replaced by a new generation
+	gen := m.gen
+	m.Update(key("enter"))
+	if m.mode != modeBrowse || m.query != "shop" || m.gen != gen+1 || m.aliases != nil || !m.loading {
+		t.Errorf("after enter: mode %v, query %q, gen %d, %d aliases, loading %v", m.mode, m.query, m.gen, len(m.aliases), m.loading)
+	}
+
+	m.Update(pageMsg{gen: gen, page: 0, aliases: aliases(2)})
+	if len(m.aliases) != 0 {
+		t.Errorf("a page of the previous list was kept")
+	}
+	m.Update(pageMsg{gen: m.gen, page: 0, aliases: aliases(1)})
+
+	// Esc in filter mode clears the search and reloads every alias
+	m.Update(key("/"))
+	m.Update(key("esc"))
+	if m.mode != modeBrowse || m.filter != "" || m.query != "" || m.gen != gen+2 {
+		t.Errorf("after esc: mode %v, filter %q, query %q, gen %d", m.mode, m.filter, m.query, m.gen)
+	}
+}
+
+func TestUpdate_ContactToggle(t *testing.T) {
+	m := testModel(t, "")
+	m.Update(pageMsg{page: 0, aliases: aliases(1)})
+	m.Update(contactsMsg{aliasID: 1, contacts: []simplelogin.AliasContact{
+		{ID: 10, Contact: "shop@example.net"},
+		{ID: 11, Contact: "bank@example.net"},
+	}})
+
+	m.Update(key("c"))
+	if m.mode != modeContacts {
+		t.Fatalf("mode = %v, want contacts", m.mode)
+	}
+
+	m.Update(contactMsg{aliasID: 1, contactID: 11, blocked: true})
+	if !m.contacts[1][1].BlockForward || m.contacts[1][0].BlockForward {
+		t.Errorf("contacts = %+v, want only bank@example.net blocked", m.contacts[1])
+	}
+	if m.status != "Blocked bank@example.net" {
+		t.Errorf("status = %q", m.status)
+	}
+
+	m.Update(key("esc"))
+	if m.mode != modeBrowse {
+		t.Errorf("mode = %v after esc, want browse", m.mode)
+	}
+}
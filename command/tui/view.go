@@ -0,0 +1,234 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	reverse = "\x1b[7m"
+	bold    = "\x1b[1m"
+	faint   = "\x1b[2m"
+	red     = "\x1b[31m"
+	reset   = "\x1b[0m"
+)
+
+// The screen is a header line, the list and detail panes, and two footer lines
+const chromeHeight = 3
+
+func (m *model) listHeight() int {
+	return max(m.height-chromeHeight, 1)
+}
+
+func (m *model) View() string {
+	if m.width == 0 {
+		return "Loading..."
+	}
+
+	listWidth := min(max(m.width*2/5, 30), m.width)
+	detailWidth := max(m.width-listWidth-3, 0)
+
+	list := m.listLines(listWidth)
+	detail := m.detailLines(detailWidth)
+
+	var b strings.Builder
+	b.WriteString(bold + fit(m.header(), m.width) + reset + "\n")
+
+	for i := range m.listHeight() {
+		var left, right string
+		if i < len(list) {
+			left = list[i]
+		} else {
+			left = strings.Repeat(" ", listWidth)
+		}
+		if i < len(detail) {
+			right = detail[i]
+		}
+		b.WriteString(left + faint + " │ " + reset + right + "\n")
+	}
+
+	b.WriteString(m.statusLine() + "\n")
+	b.WriteString(faint + fit(m.help(), m.width) + reset)
+
+	return b.String()
+}
+
+func (m *model) header() string {
+	count := fmt.Sprintf("%d aliases", len(m.visible()))
+	if !m.done {
+		count = fmt.Sprintf("%d aliases loaded", len(m.aliases))
+	}
+	if m.loading {
+		count += ", loading..."
+	}
+
+	header := "SimpleLogin — " + count
+	if m.query != "" {
+		header += fmt.Sprintf(" — search: %s", m.query)
+	}
+	return header
+}
+
+func (m *model) listLines(width int) []string {
+	aliases := m.visible()
+
+	var lines []string
+	for i := m.offset; i < len(aliases) && len(lines) < m.listHeight(); i++ {
+		alias := aliases[i]
+
+		state := "+"
+		if !alias.Enabled {
+			state = "-"
+		}
+		pin := " "
+		if alias.Pinned {
+			pin = "*"
+		}
+
+		line := fit(fmt.Sprintf("%s%s %s", state, pin, alias.Email), width)
+		switch {
+		case i == m.cursor && m.mode != modeContacts:
+			line = reverse + line + reset
+		case i == m.cursor:
+			line = bold + line + reset
+		case !alias.Enabled:
+			line = faint + line + reset
+		}
+		lines = append(lines, line)
+	}
+
+	if len(aliases) == 0 && !m.loading {
+		lines = append(lines, fit("No aliases", width))
+	}
+
+	return lines
+}
+
+func (m *model) detailLines(width int) []string {
+	alias := m.selected()
+	if alias == nil || width == 0 {
+		return nil
+	}
+
+	var lines []string
+	add := func(format string, args ...any) {
+		lines = append(lines, fit(fmt.Sprintf(format, args...), width))
+	}
+
+	lines = append(lines, bold+fit(alias.Email, width)+reset)
+	if alias.Name != "" {
+		add("Name:      %s", alias.Name)
+	}
+
+	status := "enabled"
+	if !alias.Enabled {
+		status = "disabled"
+	}
+	if alias.Pinned {
+		status += ", pinned"
+	}
+	add("Status:    %s", status)
+	add("ID:        %d", alias.ID)
+	add("Created:   %s", alias.CreationDate)
+	add("Emails:    %d forwarded, %d replied, %d blocked", alias.NbForward, alias.NbReply, alias.NbBlock)
+
+	add("")
+	add("Mailboxes:")
+	for _, mailbox := range alias.Mailboxes {
+		add("  %s", mailbox.Email)
+	}
+
+	add("")
+	add("Latest activity:")
+	if activity := alias.LatestActivity; activity.Timestamp > 0 {
+		add("  %s  %s", formatTimestamp(activity.Timestamp), activity.Action)
+		add("  %s -> %s", activity.From, activity.To)
+	} else {
+		add("  none")
+	}
+
+	add("")
+	add("Note:")
+	if alias.Note == "" {
+		add("  none")
+	}
+	for _, line := range strings.Split(alias.Note, "\n") {
+		if line != "" {
+			add("  %s", line)
+		}
+	}
+
+	add("")
+	lines = append(lines, m.contactLines(alias, width)...)
+
+	return lines
+}
+
+func (m *model) contactLines(alias *simplelogin.Alias, width int) []string {
+	contacts, ok := m.contacts[alias.ID]
+	if !ok {
+		return []string{fit("Contacts: loading...", width)}
+	}
+
+	lines := []string{fit(fmt.Sprintf("Contacts (%d):", len(contacts)), width)}
+	for i, contact := range contacts {
+		last := "never"
+		if contact.LastEmailSentTimestamp > 0 {
+			last = formatTimestamp(contact.LastEmailSentTimestamp)
+		}
+
+		line := fmt.Sprintf("  %s  last email %s", contact.Contact, last)
+		if contact.BlockForward {
+			line += "  [blocked]"
+		}
+		line = fit(line, width)
+
+		switch {
+		case m.mode == modeContacts && i == m.contactCursor:
+			line = reverse + line + reset
+		case contact.BlockForward:
+			line = faint + line + reset
+		}
+		lines = append(lines, line)
+	}
+
+	return lines
+}
+
+func (m *model) statusLine() string {
+	switch {
+	case m.mode == modeFilter:
+		return fit("/"+m.filter+"█", m.width)
+	case m.err != nil:
+		// API errors may carry a multi-line response body
+		return red + fit("Error: "+strings.Join(strings.Fields(m.err.Error()), " "), m.width) + reset
+	case m.filter != "" && m.filter != m.query:
+		return fit("Filter: "+m.filter+" (loaded aliases only, / then enter to search)", m.width)
+	default:
+		return fit(m.status, m.width)
+	}
+}
+
+func (m *model) help() string {
+	switch m.mode {
+	case modeFilter:
+		return "enter search  esc clear"
+	case modeContacts:
+		return "↑/↓ select  b block/unblock  esc back  q quit"
+	default:
+		return "↑/↓ move  / filter  t toggle  p pin  n note  y copy  c contacts  r refresh  q quit"
+	}
+}
+
+func formatTimestamp(timestamp int) string {
+	return time.Unix(int64(timestamp), 0).Format("2006-01-02 15:04")
+}
+
+// fit truncates or pads s to exactly width terminal cells
+func fit(s string, width int) string {
+	return runewidth.FillRight(runewidth.Truncate(s, width, "…"), width)
+}
@@ -0,0 +1,215 @@
+package undo
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/juli3nk/go-utils"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/internal/display"
+	"github.com/juli3nk/simplelogin-cli/internal/journal"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+var (
+	compact   bool
+	noHeaders bool
+
+	list bool
+)
+
+func NewCommand(outputFormat *string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "undo [entry_id]",
+		Short:             "Revert the latest alias or contact change",
+		Long:              undoDescription,
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: cobra.NoFileCompletions,
+		Run: func(cmd *cobra.Command, args []string) {
+			runUndo(outputFormat, args)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.BoolVar(&compact, "compact", false, "Compact output")
+	flags.BoolVar(&noHeaders, "no-headers", false, "Hide table headers")
+	flags.BoolVarP(&list, "list", "l", false, "List the changes that can be reverted")
+
+	return cmd
+}
+
+func runUndo(outputFormat *string, args []string) {
+	defer utils.RecoverFunc()
+
+	path, err := journal.Path(config.ActiveProfile())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	j, err := journal.Load(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if list {
+		displayJournal(outputFormat, j.Entries)
+		return
+	}
+
+	id := 0
+	if len(args) > 0 {
+		if id, err = strconv.Atoi(args[0]); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	entry, ok := j.Find(id)
+	if !ok {
+		if id != 0 {
+			log.Fatalf("journal entry %d not found, see 'simplelogin-cli undo --list'", id)
+		}
+		log.Fatal("nothing to undo")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	apiKey, err := config.LoadApiKey()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := revert(client, entry); err != nil {
+		log.Fatal(err)
+	}
+
+	if err := j.Remove(entry.ID); err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Reverted %s of %s: %s\n", entry.Action, entry.Target(), entry.Before)
+}
+
+// revert restores the state recorded before a change. Toggles are checked
+// against the current state so that reverting twice is harmless.
+func revert(client *simplelogin.Client, entry journal.Entry) error {
+	before := entry.Before
+
+	switch entry.Action {
+	case journal.ActionAliasToggle:
+		if before.Enabled == nil {
+			return fmt.Errorf("journal entry %d has no previous state", entry.ID)
+		}
+
+		alias, err := client.GetAlias(entry.AliasID)
+		if err != nil {
+			return err
+		}
+		if alias.Enabled == *before.Enabled {
+			return nil
+		}
+
+		_, err = client.ToggleAlias(entry.AliasID)
+		return err
+
+	case journal.ActionAliasUpdate:
+		if before.IsEmpty() {
+			return fmt.Errorf("journal entry %d has no previous state", entry.ID)
+		}
+
+		return client.UpdateAlias(entry.AliasID, simplelogin.AliasUpdateOptions{
+			Note:       before.Note,
+			Name:       before.Name,
+			Pinned:     before.Pinned,
+			MailboxIDs: before.MailboxIDs,
+		})
+
+	case journal.ActionContactBlock:
+		if before.Blocked == nil {
+			return fmt.Errorf("journal entry %d has no previous state", entry.ID)
+		}
+
+		// The API can only toggle a contact and does not return its state,
+		// so toggle back when the first toggle did not restore it
+		contact, err := client.ToggleContact(entry.ContactID)
+		if err != nil {
+			return err
+		}
+		if contact.BlockForward != *before.Blocked {
+			_, err = client.ToggleContact(entry.ContactID)
+		}
+		return err
+
+	default:
+		return fmt.Errorf("cannot revert %q changes", entry.Action)
+	}
+}
+
+func displayJournal(outputFormat *string, entries []journal.Entry) {
+	switch *outputFormat {
+	case "json":
+		if err := display.DisplayData(entries, &display.DisplayOptions{
+			Format:  display.FormatJSON,
+			Compact: compact,
+		}); err != nil {
+			log.Fatal(err)
+		}
+	default: // table
+		if len(entries) == 0 {
+			fmt.Println("Nothing to undo.")
+			return
+		}
+
+		tableOpts := display.DefaultTableOptions()
+		if noHeaders {
+			tableOpts.NoHeaders = true
+		}
+		if compact {
+			tableOpts = display.CompactTableOptions()
+		}
+
+		table := display.NewTable(tableOpts)
+		table.SetHeader([]string{"ID", "Date", "Action", "Target", "Before"})
+
+		// Latest first, the entry 'undo' reverts by default
+		for i := len(entries) - 1; i >= 0; i-- {
+			entry := entries[i]
+			table.Append([]string{
+				display.FormatID(entry.ID),
+				entry.Time.Local().Format(time.DateTime),
+				entry.Action,
+				entry.Target(),
+				entry.Before.String(),
+			})
+		}
+
+		table.Render()
+	}
+}
+
+const undoDescription = `
+Revert the latest alias or contact change
+
+Alias toggles and updates (including tags and notes) and contact blocks made
+with this CLI are recorded in a local journal with the state they replaced,
+per profile in ~/.config/simplelogin-cli/journal-<profile>.json. The last
+100 changes are kept.
+
+'undo' reverts the latest change, or the one given by ID, and removes it
+from the journal. Deletions cannot be reverted.
+
+Examples:
+  simplelogin-cli undo
+  simplelogin-cli undo --list
+  simplelogin-cli undo 12
+`
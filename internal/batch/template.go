@@ -0,0 +1,143 @@
+package batch
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// placeholder matches {name} and {name:0N}
+var placeholder = regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z0-9_]*)(?::0?(\d+))?\}`)
+
+// Template generates alias prefixes such as "qa-{run}-user{n:02}"
+// {n} is the sequence number, other placeholders come from variables,
+// {run} defaulting to RunID (see WithDefaults).
+// A ":0N" modifier zero pads the value to N digits.
+type Template struct {
+	raw string
+}
+
+// RunID returns a short identifier of a batch run: the seconds since the
+// epoch in base 36, e.g. "s44we8"
+func RunID(now time.Time) string {
+	return strconv.FormatInt(now.Unix(), 36)
+}
+
+// WithDefaults returns a copy of vars with {run} set to RunID(now) when unset
+func WithDefaults(vars map[string]string, now time.Time) map[string]string {
+	result := make(map[string]string, len(vars)+1)
+	for key, value := range vars {
+		result[key] = value
+	}
+	if _, ok := result["run"]; !ok {
+		result["run"] = RunID(now)
+	}
+	return result
+}
+
+// Parse validates a template
+func Parse(raw string) (*Template, error) {
+	if raw == "" {
+		return nil, fmt.Errorf("empty template")
+	}
+
+	stripped := placeholder.ReplaceAllString(raw, "")
+	if strings.ContainsAny(stripped, "{}") {
+		return nil, fmt.Errorf("invalid placeholder in template %q", raw)
+	}
+
+	return &Template{raw: raw}, nil
+}
+
+// String returns the template source
+func (t *Template) String() string {
+	return t.raw
+}
+
+// Expand renders the template for sequence number n
+func (t *Template) Expand(n int, vars map[string]string) (string, error) {
+	var expandErr error
+
+	result := placeholder.ReplaceAllStringFunc(t.raw, func(match string) string {
+		groups := placeholder.FindStringSubmatch(match)
+		name, width := groups[1], groups[2]
+
+		var value string
+		if name == "n" {
+			value = strconv.Itoa(n)
+		} else if v, ok := vars[name]; ok {
+			value = v
+		} else {
+			expandErr = fmt.Errorf("no value for {%s}", name)
+			return match
+		}
+
+		if width != "" {
+			w, _ := strconv.Atoi(width)
+			if len(value) < w {
+				value = strings.Repeat("0", w-len(value)) + value
+			}
+		}
+
+		return value
+	})
+
+	return result, expandErr
+}
+
+// Name renders the template with the variables but leaves {n} untouched,
+// identifying the whole batch
+func (t *Template) Name(vars map[string]string) (string, error) {
+	var expandErr error
+
+	result := placeholder.ReplaceAllStringFunc(t.raw, func(match string) string {
+		name := placeholder.FindStringSubmatch(match)[1]
+		if name == "n" {
+			return match
+		}
+		if v, ok := vars[name]; ok {
+			return v
+		}
+		expandErr = fmt.Errorf("no value for {%s}", name)
+		return match
+	})
+
+	return result, expandErr
+}
+
+// Regexp returns a regular expression matching every prefix the template can
+// generate with these variables
+func (t *Template) Regexp(vars map[string]string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+
+	last := 0
+	for _, loc := range placeholder.FindAllStringSubmatchIndex(t.raw, -1) {
+		b.WriteString(regexp.QuoteMeta(t.raw[last:loc[0]]))
+
+		name := t.raw[loc[2]:loc[3]]
+		if name == "n" {
+			b.WriteString(`\d+`)
+		} else if v, ok := vars[name]; ok {
+			b.WriteString(regexp.QuoteMeta(v))
+		} else {
+			return nil, fmt.Errorf("no value for {%s}", name)
+		}
+
+		last = loc[1]
+	}
+	b.WriteString(regexp.QuoteMeta(t.raw[last:]))
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+// LiteralPrefix returns the template text before its first placeholder
+func (t *Template) LiteralPrefix() string {
+	if loc := placeholder.FindStringIndex(t.raw); loc != nil {
+		return t.raw[:loc[0]]
+	}
+	return t.raw
+}
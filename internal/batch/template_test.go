@@ -0,0 +1,92 @@
+package batch
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTemplate_Expand(t *testing.T) {
+	tmpl, err := Parse("qa-{run}-user{n:02}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vars := map[string]string{"run": "run42"}
+
+	got, err := tmpl.Expand(7, vars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "qa-run42-user07" {
+		t.Errorf("Expand() = %q, want %q", got, "qa-run42-user07")
+	}
+
+	got, _ = tmpl.Expand(123, vars)
+	if got != "qa-run42-user123" {
+		t.Errorf("Expand() = %q, want %q", got, "qa-run42-user123")
+	}
+
+	if _, err := tmpl.Expand(1, nil); err == nil {
+		t.Error("Expand() without {run} expected an error")
+	}
+
+	name, _ := tmpl.Name(vars)
+	if name != "qa-run42-user{n:02}" {
+		t.Errorf("Name() = %q", name)
+	}
+}
+
+func TestTemplate_Regexp(t *testing.T) {
+	tmpl, err := Parse("qa.{run}-user{n:02}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	re, err := tmpl.Regexp(map[string]string{"run": "42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for prefix, want := range map[string]bool{
+		"qa.42-user01":  true,
+		"qa.42-user150": true,
+		"qa.43-user01":  false,
+		"qaX42-user01":  false,
+		"qa.42-user":    false,
+	} {
+		if got := re.MatchString(prefix); got != want {
+			t.Errorf("Regexp().MatchString(%q) = %t, want %t", prefix, got, want)
+		}
+	}
+
+	if tmpl.LiteralPrefix() != "qa." {
+		t.Errorf("LiteralPrefix() = %q", tmpl.LiteralPrefix())
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, raw := range []string{"", "user{n", "user{1n}", "user}"} {
+		if _, err := Parse(raw); err == nil {
+			t.Errorf("Parse(%q) expected an error", raw)
+		}
+	}
+}
+
+func TestWithDefaults(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	vars := WithDefaults(nil, now)
+	if vars["run"] != "s44we8" {
+		t.Errorf("default run = %q, want %q", vars["run"], "s44we8")
+	}
+
+	tmpl, _ := Parse("qa-{run}-user{n:02}")
+	if got, err := tmpl.Expand(1, vars); err != nil || got != "qa-s44we8-user01" {
+		t.Errorf("Expand() = %q, %v", got, err)
+	}
+
+	given := map[string]string{"run": "run42"}
+	if vars := WithDefaults(given, now); vars["run"] != "run42" {
+		t.Errorf("WithDefaults() overrode run = %q", vars["run"])
+	}
+}
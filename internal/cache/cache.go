@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache stores JSON values in files that expire after a TTL. It keeps
+// shell completion fast without hitting the API on every key press.
+type Cache struct {
+	dir string
+	ttl time.Duration
+}
+
+// New returns a cache in dir
+func New(dir string, ttl time.Duration) *Cache {
+	return &Cache{dir: dir, ttl: ttl}
+}
+
+// Dir returns the default cache directory of a profile
+func Dir(profile string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "simplelogin-cli", profile), nil
+}
+
+type entry[T any] struct {
+	StoredAt time.Time `json:"stored_at"`
+	Value    T         `json:"value"`
+}
+
+// Get returns the cached value of key, calling fetch and storing its result
+// when the entry is missing or expired. Cache write errors are ignored.
+func Get[T any](c *Cache, key string, fetch func() (T, error)) (T, error) {
+	path := filepath.Join(c.dir, key+".json")
+
+	if data, err := os.ReadFile(path); err == nil {
+		var e entry[T]
+		if json.Unmarshal(data, &e) == nil && time.Since(e.StoredAt) < c.ttl {
+			return e.Value, nil
+		}
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+
+	if data, err := json.Marshal(entry[T]{StoredAt: time.Now(), Value: value}); err == nil {
+		if os.MkdirAll(c.dir, 0700) == nil {
+			os.WriteFile(path, data, 0600)
+		}
+	}
+
+	return value, nil
+}
+
+// Clear removes every cached entry
+func (c *Cache) Clear() error {
+	return os.RemoveAll(c.dir)
+}
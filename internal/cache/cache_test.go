@@ -0,0 +1,37 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestGet(t *testing.T) {
+	c := New(t.TempDir(), time.Minute)
+
+	calls := 0
+	fetch := func() ([]string, error) {
+		calls++
+		return []string{"a", "b"}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		got, err := Get(c, "items", fetch)
+		if err != nil || len(got) != 2 {
+			t.Fatalf("Get() = %v, %v", got, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("fetch called %d times, want 1", calls)
+	}
+
+	expired := New(c.dir, 0)
+	if _, err := Get(expired, "items", fetch); err != nil || calls != 2 {
+		t.Errorf("expired entry not refetched, calls = %d", calls)
+	}
+
+	_, err := Get(c, "failing", func() (int, error) { return 0, errors.New("offline") })
+	if err == nil {
+		t.Error("Get() hid the fetch error")
+	}
+}
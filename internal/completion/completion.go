@@ -0,0 +1,300 @@
+package completion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juli3nk/simplelogin-cli/internal/cache"
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"github.com/spf13/cobra"
+)
+
+// TTL is how long completion results are reused
+const TTL = time.Minute
+
+// ValidArgsFunc completes positional arguments
+type ValidArgsFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+type aliasEntry struct {
+	ID      int    `json:"id"`
+	Email   string `json:"email"`
+	Enabled bool   `json:"enabled"`
+}
+
+type mailboxEntry struct {
+	ID      int    `json:"id"`
+	Email   string `json:"email"`
+	Default bool   `json:"default"`
+}
+
+type domainEntry struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// AliasIDs completes alias IDs, described by their email
+func AliasIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	aliases, err := loadAliases(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		completions = append(completions, fmt.Sprintf("%d\t%s%s", alias.ID, alias.Email, disabledSuffix(alias.Enabled)))
+	}
+
+	return filter(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// AliasEmails completes alias emails
+func AliasEmails(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	aliases, err := loadAliases(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(aliases))
+	for _, alias := range aliases {
+		completions = append(completions, fmt.Sprintf("%s\tID %d%s", alias.Email, alias.ID, disabledSuffix(alias.Enabled)))
+	}
+
+	return filter(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// MailboxIDs completes mailbox IDs, described by their email
+func MailboxIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	mailboxes, err := loadMailboxes(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(mailboxes))
+	for _, mailbox := range mailboxes {
+		completions = append(completions, fmt.Sprintf("%d\t%s%s", mailbox.ID, mailbox.Email, defaultSuffix(mailbox.Default)))
+	}
+
+	return filter(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// MailboxEmails completes mailbox emails
+func MailboxEmails(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	mailboxes, err := loadMailboxes(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(mailboxes))
+	for _, mailbox := range mailboxes {
+		completions = append(completions, fmt.Sprintf("%s\tID %d%s", mailbox.Email, mailbox.ID, defaultSuffix(mailbox.Default)))
+	}
+
+	return filter(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// DomainIDs completes custom domain IDs, described by their name
+func DomainIDs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	domains, err := loadDomains(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		completions = append(completions, fmt.Sprintf("%d\t%s", domain.ID, domain.Name))
+	}
+
+	return filter(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// DomainNames completes custom domain names
+func DomainNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	domains, err := loadDomains(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		completions = append(completions, fmt.Sprintf("%s\tID %d", domain.Name, domain.ID))
+	}
+
+	return filter(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// DomainSuffixes completes custom domains as alias suffixes, e.g. @example.com
+func DomainSuffixes(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	domains, err := loadDomains(cmd)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	completions := make([]string, 0, len(domains))
+	for _, domain := range domains {
+		completions = append(completions, "@"+domain.Name+"\tcustom domain")
+	}
+
+	return filter(completions, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// FirstArg applies fn to the first positional argument only
+func FirstArg(fn ValidArgsFunc) ValidArgsFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return fn(cmd, args, toComplete)
+	}
+}
+
+// List applies fn to the last item of a comma separated list flag, such as
+// --mailbox-ids 1,<TAB>, leaving out the items already given
+func List(fn ValidArgsFunc) ValidArgsFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		i := strings.LastIndex(toComplete, ",")
+		if i < 0 {
+			return fn(cmd, args, toComplete)
+		}
+
+		head, last := toComplete[:i+1], toComplete[i+1:]
+		given := map[string]bool{}
+		for _, item := range strings.Split(head, ",") {
+			given[strings.ToLower(strings.TrimSpace(item))] = true
+		}
+
+		completions, directive := fn(cmd, args, last)
+
+		var result []string
+		for _, completion := range completions {
+			value, _, _ := strings.Cut(completion, "\t")
+			if !given[strings.ToLower(value)] {
+				result = append(result, head+completion)
+			}
+		}
+		return result, directive
+	}
+}
+
+func loadAliases(cmd *cobra.Command) ([]aliasEntry, error) {
+	return cached(cmd, "aliases", func(client *simplelogin.Client) ([]aliasEntry, error) {
+		aliases, err := client.GetAllAliases(simplelogin.AliasListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]aliasEntry, len(aliases))
+		for i, alias := range aliases {
+			entries[i] = aliasEntry{ID: alias.ID, Email: alias.Email, Enabled: alias.Enabled}
+		}
+		return entries, nil
+	})
+}
+
+func loadMailboxes(cmd *cobra.Command) ([]mailboxEntry, error) {
+	return cached(cmd, "mailboxes", func(client *simplelogin.Client) ([]mailboxEntry, error) {
+		mailboxes, err := client.GetMailboxes()
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]mailboxEntry, len(mailboxes))
+		for i, mailbox := range mailboxes {
+			entries[i] = mailboxEntry{ID: mailbox.ID, Email: mailbox.Email, Default: mailbox.Default}
+		}
+		return entries, nil
+	})
+}
+
+func loadDomains(cmd *cobra.Command) ([]domainEntry, error) {
+	return cached(cmd, "domains", func(client *simplelogin.Client) ([]domainEntry, error) {
+		domains, err := client.GetDomains()
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]domainEntry, len(domains))
+		for i, domain := range domains {
+			entries[i] = domainEntry{ID: domain.ID, Name: domain.DomainName}
+		}
+		return entries, nil
+	})
+}
+
+// cached returns the cached value of key for the active profile, fetching it with a new client
+func cached[T any](cmd *cobra.Command, key string, fetch func(*simplelogin.Client) (T, error)) (T, error) {
+	var zero T
+
+	// The root pre-run hook does not run while completing
+	if profile, err := cmd.Flags().GetString("profile"); err == nil && profile != "" {
+		if err := config.SetProfile(profile); err != nil {
+			return zero, err
+		}
+	}
+
+	dir, err := cache.Dir(config.ActiveProfile())
+	if err != nil {
+		return zero, err
+	}
+
+	return cache.Get(cache.New(dir, TTL), "completion-"+key, func() (T, error) {
+		cfg, err := config.Load()
+		if err != nil {
+			return zero, err
+		}
+
+		// The file backend asks for a passphrase, which a shell completion cannot do
+		backend, _, err := config.ApiKeyBackend()
+		if err != nil {
+			return zero, err
+		}
+		if backend == config.BackendFile {
+			return zero, errors.New("completion is not available with the file backend")
+		}
+
+		apiKey, err := config.LoadApiKey()
+		if err != nil {
+			return zero, err
+		}
+
+		client, err := simplelogin.NewClient(cfg.ApiURL, apiKey)
+		if err != nil {
+			return zero, err
+		}
+
+		return fetch(client)
+	})
+}
+
+// filter keeps the completions starting with toComplete; zsh and fish
+// filter themselves but bash does not
+func filter(completions []string, toComplete string) []string {
+	if toComplete == "" {
+		return completions
+	}
+
+	var result []string
+	for _, completion := range completions {
+		value, _, _ := strings.Cut(completion, "\t")
+		if strings.HasPrefix(strings.ToLower(value), strings.ToLower(toComplete)) {
+			result = append(result, completion)
+		}
+	}
+	return result
+}
+
+func disabledSuffix(enabled bool) string {
+	if enabled {
+		return ""
+	}
+	return " (disabled)"
+}
+
+func defaultSuffix(isDefault bool) string {
+	if isDefault {
+		return " (default)"
+	}
+	return ""
+}
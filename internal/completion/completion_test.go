@@ -0,0 +1,33 @@
+package completion
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestList(t *testing.T) {
+	mailboxes := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return filter([]string{"1\tme@example.org", "2\twork@example.org", "12\tqa@example.org"}, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	tests := []struct {
+		toComplete string
+		want       []string
+	}{
+		{toComplete: "1", want: []string{"1\tme@example.org", "12\tqa@example.org"}},
+		{toComplete: "1,", want: []string{"1,2\twork@example.org", "1,12\tqa@example.org"}},
+		{toComplete: "2,1", want: []string{"2,1\tme@example.org", "2,12\tqa@example.org"}},
+		{toComplete: "1,2,", want: []string{"1,2,12\tqa@example.org"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.toComplete, func(t *testing.T) {
+			got, _ := List(mailboxes)(nil, nil, tt.toComplete)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("List()(%q) = %q, want %q", tt.toComplete, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,12 +3,77 @@ package config
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"github.com/juli3nk/simplelogin-cli/internal/dnsrecords"
+)
+
+// Environment variables overriding the stored configuration
+const (
+	EnvApiKey = "SIMPLELOGIN_API_KEY"
+	EnvApiURL = "SIMPLELOGIN_API_URL"
+
+	EnvIMAPPassword = "SIMPLELOGIN_IMAP_PASSWORD"
 )
 
 type Config struct {
-	ApiURL *string `json:"api_url"`
+	ApiURL        *string               `json:"api_url"`
+	ApiKeyCommand *string               `json:"api_key_command,omitempty"`
+	IMAP          map[string]IMAPConfig `json:"imap,omitempty"`
+	Profiles      map[string]Profile    `json:"profiles,omitempty"`
+	Protect       *ProtectConfig        `json:"protect,omitempty"`
+	// DNS overrides the mail setup of a self-hosted instance when the
+	// one derived from the API URL is wrong
+	DNS *dnsrecords.Instance `json:"dns,omitempty"`
+}
+
+// ProtectConfig lists the aliases that delete commands refuse to delete
+type ProtectConfig struct {
+	Pinned   bool     `json:"pinned,omitempty"`
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// IMAPConfig describes the IMAP access to a mailbox, keyed by mailbox email
+type IMAPConfig struct {
+	Address         string `json:"address"`
+	Username        string `json:"username"`
+	Password        string `json:"password,omitempty"`
+	PasswordCommand string `json:"password_command,omitempty"`
+	TLS             *bool  `json:"tls,omitempty"`
+	Folder          string `json:"folder,omitempty"`
+}
+
+// IMAPConfigFor returns the IMAP configuration of a mailbox
+func (c *Config) IMAPConfigFor(mailbox string) (IMAPConfig, bool) {
+	for email, imapConfig := range c.IMAP {
+		if strings.EqualFold(email, mailbox) {
+			return imapConfig, true
+		}
+	}
+	return IMAPConfig{}, false
+}
+
+// UseTLS reports whether the connection uses implicit TLS, the default
+func (c IMAPConfig) UseTLS() bool {
+	return c.TLS == nil || *c.TLS
+}
+
+// LoadPassword returns the IMAP password from the environment, the
+// configuration or the password command, in that order
+func (c IMAPConfig) LoadPassword() (string, error) {
+	if password := os.Getenv(EnvIMAPPassword); password != "" {
+		return password, nil
+	}
+	if c.Password != "" {
+		return c.Password, nil
+	}
+	if c.PasswordCommand != "" {
+		return runHelper(c.PasswordCommand)
+	}
+	return "", fmt.Errorf("no IMAP password configured for %s", c.Username)
 }
 
 func configPath() (string, error) {
@@ -19,22 +84,44 @@ func configPath() (string, error) {
 	return filepath.Join(home, ".config", "simplelogin-cli", "config.json"), nil
 }
 
+// Load loads the configuration of the active profile
 func Load() (*Config, error) {
+	return LoadProfile(ActiveProfile())
+}
+
+// LoadProfile loads the configuration with the API URL and key command of a profile
+func LoadProfile(profile string) (*Config, error) {
 	path, err := configPath()
 	if err != nil {
 		return nil, err
 	}
 
+	var cfg Config
+
 	data, err := os.ReadFile(path)
-	if errors.Is(err, os.ErrNotExist) {
-		return &Config{}, nil
-	} else if err != nil {
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
 		return nil, err
 	}
+	if err == nil {
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return nil, err
+		}
+	}
 
-	var cfg Config
-	if err := json.Unmarshal(data, &cfg); err != nil {
-		return nil, err
+	if profile != DefaultProfile {
+		p, ok := cfg.Profiles[profile]
+		if !ok {
+			return nil, fmt.Errorf("unknown profile %q, add it under \"profiles\" in %s", profile, path)
+		}
+		cfg.ApiURL = p.ApiURL
+		cfg.ApiKeyCommand = p.ApiKeyCommand
+		cfg.DNS = p.DNS
+	}
+
+	// The environment only overrides the active profile, so that commands
+	// working on two profiles do not point both at the same instance
+	if apiURL := os.Getenv(EnvApiURL); apiURL != "" && profile == ActiveProfile() {
+		cfg.ApiURL = &apiURL
 	}
 
 	return &cfg, nil
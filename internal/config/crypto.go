@@ -0,0 +1,142 @@
+package config
+
+import (
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/juli3nk/simplelogin-cli/internal/prompt"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+// EnvPassphrase holds the passphrase protecting the credentials file
+const EnvPassphrase = "SIMPLELOGIN_PASSPHRASE"
+
+const (
+	credentialsVersion = 1
+
+	scryptN      = 1 << 15
+	scryptR      = 8
+	scryptP      = 1
+	scryptKeyLen = chacha20poly1305.KeySize
+	saltLen      = 16
+)
+
+// encryptedCredentials is the on-disk format of the credentials file
+type encryptedCredentials struct {
+	Version    int    `json:"version,omitempty"`
+	KDF        string `json:"kdf,omitempty"`
+	Salt       string `json:"salt,omitempty"`
+	Nonce      string `json:"nonce,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+
+	// ApiKey is only set by files written before encryption was introduced
+	ApiKey string `json:"api_key,omitempty"`
+}
+
+// encryptApiKey seals the API key with a key derived from the passphrase
+// using scrypt and XChaCha20-Poly1305
+func encryptApiKey(apiKey, passphrase string) (*encryptedCredentials, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required")
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	ciphertext := aead.Seal(nil, nonce, []byte(apiKey), nil)
+
+	return &encryptedCredentials{
+		Version:    credentialsVersion,
+		KDF:        "scrypt",
+		Salt:       base64.StdEncoding.EncodeToString(salt),
+		Nonce:      base64.StdEncoding.EncodeToString(nonce),
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+// decryptApiKey opens credentials sealed by encryptApiKey
+func decryptApiKey(creds *encryptedCredentials, passphrase string) (string, error) {
+	if creds.Version != credentialsVersion || creds.KDF != "scrypt" {
+		return "", fmt.Errorf("unsupported credentials file (version %d, kdf %q)", creds.Version, creds.KDF)
+	}
+
+	salt, err := base64.StdEncoding.DecodeString(creds.Salt)
+	if err != nil {
+		return "", fmt.Errorf("invalid salt: %w", err)
+	}
+	nonce, err := base64.StdEncoding.DecodeString(creds.Nonce)
+	if err != nil {
+		return "", fmt.Errorf("invalid nonce: %w", err)
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(creds.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("invalid ciphertext: %w", err)
+	}
+
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return "", errors.New("invalid nonce size")
+	}
+
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.New("wrong passphrase or corrupted credentials file")
+	}
+
+	return string(plaintext), nil
+}
+
+func newAEAD(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptKeyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	return chacha20poly1305.NewX(key)
+}
+
+// readPassphrase returns the credentials file passphrase from the environment or a prompt
+func readPassphrase() (string, error) {
+	if p := os.Getenv(EnvPassphrase); p != "" {
+		return p, nil
+	}
+
+	p, err := prompt.Password("Credentials passphrase: ")
+	if errors.Is(err, prompt.ErrNotTerminal) {
+		return "", fmt.Errorf("credentials file is encrypted: set %s or run from a terminal", EnvPassphrase)
+	}
+	return p, err
+}
+
+// readNewPassphrase asks for a passphrase twice when it is not set in the environment
+func readNewPassphrase() (string, error) {
+	if p := os.Getenv(EnvPassphrase); p != "" {
+		return p, nil
+	}
+
+	p, err := prompt.NewPassword("Credentials passphrase: ")
+	if errors.Is(err, prompt.ErrNotTerminal) {
+		return "", fmt.Errorf("cannot encrypt credentials file: set %s or run from a terminal", EnvPassphrase)
+	}
+	return p, err
+}
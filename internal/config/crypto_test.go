@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestEncryptDecryptApiKey(t *testing.T) {
+	creds, err := encryptApiKey("my-api-key", "correct horse")
+	if err != nil {
+		t.Fatalf("encryptApiKey() error = %v", err)
+	}
+	if creds.Ciphertext == "" || creds.ApiKey != "" {
+		t.Fatalf("encryptApiKey() must not store the key in clear text: %+v", creds)
+	}
+
+	apiKey, err := decryptApiKey(creds, "correct horse")
+	if err != nil {
+		t.Fatalf("decryptApiKey() error = %v", err)
+	}
+	if apiKey != "my-api-key" {
+		t.Errorf("decryptApiKey() = %q, want %q", apiKey, "my-api-key")
+	}
+
+	if _, err := decryptApiKey(creds, "wrong"); err == nil {
+		t.Error("decryptApiKey() with wrong passphrase expected an error")
+	}
+}
+
+func TestEncryptApiKey_EmptyPassphrase(t *testing.T) {
+	if _, err := encryptApiKey("my-api-key", ""); err == nil {
+		t.Error("encryptApiKey() with empty passphrase expected an error")
+	}
+}
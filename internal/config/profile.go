@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/juli3nk/simplelogin-cli/internal/dnsrecords"
+)
+
+// DefaultProfile is the profile used when none is selected; it reads the
+// top-level settings of the configuration file
+const DefaultProfile = "default"
+
+// EnvProfile selects the profile when --profile is not given
+const EnvProfile = "SIMPLELOGIN_PROFILE"
+
+var profileNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// activeProfile is the profile selected with --profile
+var activeProfile string
+
+// Profile holds the settings of an account or instance other than the default one
+type Profile struct {
+	ApiURL        *string              `json:"api_url"`
+	ApiKeyCommand *string              `json:"api_key_command,omitempty"`
+	DNS           *dnsrecords.Instance `json:"dns,omitempty"`
+}
+
+// SetProfile selects the profile used by Load and the API key functions
+func SetProfile(name string) error {
+	if name != "" && !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q", name)
+	}
+	activeProfile = name
+	return nil
+}
+
+// ActiveProfile returns the selected profile, from --profile, the
+// environment, or the default profile
+func ActiveProfile() string {
+	if activeProfile != "" {
+		return activeProfile
+	}
+	if name := os.Getenv(EnvProfile); name != "" && profileNamePattern.MatchString(name) {
+		return name
+	}
+	return DefaultProfile
+}
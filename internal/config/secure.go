@@ -1,66 +1,208 @@
 package config
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
 
 	"github.com/zalando/go-keyring"
 )
 
+// The keyring entry of a profile is service/<profile name>
+const service = "simplelogin-cli"
+
+// Storage backends for the API key
 const (
-	service = "simplelogin-cli"
-	user    = "default"
+	BackendEnv     = "env"
+	BackendCommand = "command"
+	BackendKeyring = "keyring"
+	BackendFile    = "file"
 )
 
+var errNoApiKey = errors.New("no API key found")
+
 func SaveApiKey(apiKey string) error {
-	err := keyring.Set(service, user, apiKey)
+	profile := ActiveProfile()
+
+	err := keyring.Set(service, profile, apiKey)
 	if err == nil {
 		return nil
 	}
 
-	// Fallback : fichier ~/.app-cli/credentials.json
-	fmt.Println("⚠️  Warning: keyring not available, falling back to local file storage.")
+	// Fallback : fichier ~/.config/simplelogin-cli/credentials.json
+	fmt.Println("⚠️  Warning: keyring not available, falling back to encrypted file storage.")
 
-	return saveApiKeyFile(apiKey)
+	return saveApiKeyFile(profile, apiKey)
 }
 
 func LoadApiKey() (string, error) {
-	apiKey, err := keyring.Get(service, user)
+	return LoadApiKeyProfile(ActiveProfile())
+}
+
+// LoadApiKeyProfile loads the API key of a profile; the environment only
+// applies to the active profile
+func LoadApiKeyProfile(profile string) (string, error) {
+	if apiKey := os.Getenv(EnvApiKey); apiKey != "" && profile == ActiveProfile() {
+		return apiKey, nil
+	}
+
+	cfg, err := LoadProfile(profile)
+	if err != nil {
+		return "", err
+	}
+	if cfg.ApiKeyCommand != nil && *cfg.ApiKeyCommand != "" {
+		apiKey, err := runHelper(*cfg.ApiKeyCommand)
+		if err != nil {
+			return "", fmt.Errorf("api_key_command: %w", err)
+		}
+		return apiKey, nil
+	}
+
+	apiKey, err := keyring.Get(service, profile)
 	if err == nil {
 		return apiKey, nil
 	}
 
 	// Fallback
-	return loadApiKeyFile()
+	return loadApiKeyFile(profile)
 }
 
 func DeleteApiKey() error {
-	err := keyring.Delete(service, user)
+	profile := ActiveProfile()
+
+	err := keyring.Delete(service, profile)
 	if err == nil {
 		return nil
 	}
 
 	// Fallback
-	return deleteApiKeyFile()
+	return deleteApiKeyFile(profile)
+}
+
+// ApiKeyBackend returns the backend the API key is currently loaded from
+// and a human readable location for it
+func ApiKeyBackend() (string, string, error) {
+	if os.Getenv(EnvApiKey) != "" {
+		return BackendEnv, EnvApiKey, nil
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		return "", "", err
+	}
+	if cfg.ApiKeyCommand != nil && *cfg.ApiKeyCommand != "" {
+		return BackendCommand, *cfg.ApiKeyCommand, nil
+	}
+
+	profile := ActiveProfile()
+	if _, err := keyring.Get(service, profile); err == nil {
+		return BackendKeyring, fmt.Sprintf("%s/%s", service, profile), nil
+	}
+
+	path, err := credsPath(profile)
+	if err != nil {
+		return "", "", err
+	}
+	if _, err := os.Stat(path); err != nil {
+		return "", "", errNoApiKey
+	}
+
+	return BackendFile, path, nil
+}
+
+// LoadApiKeyFrom loads the API key from a specific storage backend
+func LoadApiKeyFrom(backend string) (string, error) {
+	switch backend {
+	case BackendKeyring:
+		apiKey, err := keyring.Get(service, ActiveProfile())
+		if errors.Is(err, keyring.ErrNotFound) {
+			return "", errNoApiKey
+		}
+		return apiKey, err
+	case BackendFile:
+		return loadApiKeyFile(ActiveProfile())
+	default:
+		return "", fmt.Errorf("unsupported storage backend %q", backend)
+	}
+}
+
+// SaveApiKeyTo saves the API key to a specific storage backend, without fallback
+func SaveApiKeyTo(backend, apiKey string) error {
+	switch backend {
+	case BackendKeyring:
+		return keyring.Set(service, ActiveProfile(), apiKey)
+	case BackendFile:
+		return saveApiKeyFile(ActiveProfile(), apiKey)
+	default:
+		return fmt.Errorf("unsupported storage backend %q", backend)
+	}
+}
+
+// DeleteApiKeyFrom removes the API key from a specific storage backend
+func DeleteApiKeyFrom(backend string) error {
+	switch backend {
+	case BackendKeyring:
+		return keyring.Delete(service, ActiveProfile())
+	case BackendFile:
+		return deleteApiKeyFile(ActiveProfile())
+	default:
+		return fmt.Errorf("unsupported storage backend %q", backend)
+	}
+}
+
+//
+// --- External credential helper ---
+//
+
+// runHelper runs an external credential helper and returns its first output line
+func runHelper(command string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%q failed: %w: %s", command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	secret, _, _ := strings.Cut(stdout.String(), "\n")
+	secret = strings.TrimSpace(secret)
+	if secret == "" {
+		return "", fmt.Errorf("%q returned an empty secret", command)
+	}
+
+	return secret, nil
 }
 
 //
 // --- Fallback : fichier local ---
 //
 
-func credsPath() (string, error) {
+// credsPath returns the credentials file of a profile, credentials.json for
+// the default profile and credentials-<profile>.json otherwise
+func credsPath(profile string) (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(home, ".config", "simplelogin-cli", "credentials.json"), nil
+
+	name := "credentials.json"
+	if profile != DefaultProfile {
+		name = "credentials-" + profile + ".json"
+	}
+
+	return filepath.Join(home, ".config", "simplelogin-cli", name), nil
 }
 
-func saveApiKeyFile(apiKey string) error {
-	path, err := credsPath()
+func saveApiKeyFile(profile, apiKey string) error {
+	path, err := credsPath(profile)
 	if err != nil {
 		return err
 	}
@@ -69,33 +211,80 @@ func saveApiKeyFile(apiKey string) error {
 		return err
 	}
 
-	data, _ := json.MarshalIndent(map[string]string{"api_key": apiKey}, "", "  ")
-	return os.WriteFile(path, data, 0600)
+	passphrase, err := readNewPassphrase()
+	if err != nil {
+		return err
+	}
+
+	creds, err := encryptApiKey(apiKey, passphrase)
+	if err != nil {
+		return err
+	}
+
+	data, _ := json.MarshalIndent(creds, "", "  ")
+	return WriteFileAtomic(path, data)
 }
 
-func loadApiKeyFile() (string, error) {
-	path, err := credsPath()
+// WriteFileAtomic replaces path with data, readable by the owner only, so
+// that readers never see a partial file
+func WriteFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func loadApiKeyFile(profile string) (string, error) {
+	path, err := credsPath(profile)
 	if err != nil {
 		return "", err
 	}
 
 	data, err := os.ReadFile(path)
 	if errors.Is(err, os.ErrNotExist) {
-		return "", fmt.Errorf("no API key found")
+		return "", errNoApiKey
 	} else if err != nil {
 		return "", err
 	}
 
-	var obj map[string]string
-	if err := json.Unmarshal(data, &obj); err != nil {
+	var creds encryptedCredentials
+	if err := json.Unmarshal(data, &creds); err != nil {
+		return "", err
+	}
+
+	// Files written by older versions hold the key in clear text
+	if creds.Ciphertext == "" {
+		if creds.ApiKey != "" {
+			fmt.Fprintln(os.Stderr, "⚠️  Warning: API key stored in clear text, run 'simplelogin-cli auth migrate-storage --to file' to encrypt it.")
+		}
+		return creds.ApiKey, nil
+	}
+
+	passphrase, err := readPassphrase()
+	if err != nil {
 		return "", err
 	}
 
-	return obj["api_key"], nil
+	return decryptApiKey(&creds, passphrase)
 }
 
-func deleteApiKeyFile() error {
-	path, err := credsPath()
+func deleteApiKeyFile(profile string) error {
+	path, err := credsPath(profile)
 	if err != nil {
 		return err
 	}
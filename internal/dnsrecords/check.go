@@ -0,0 +1,254 @@
+package dnsrecords
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Resolver looks up DNS records; *net.Resolver implements it
+type Resolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupCNAME(ctx context.Context, host string) (string, error)
+}
+
+// NewResolver returns a resolver querying the DNS server at address
+// (host or host:port), or the system resolver when address is empty
+func NewResolver(address string) Resolver {
+	if address == "" {
+		return net.DefaultResolver
+	}
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		address = net.JoinHostPort(address, "53")
+	}
+
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			dialer := net.Dialer{Timeout: 5 * time.Second}
+			return dialer.DialContext(ctx, network, address)
+		},
+	}
+}
+
+// Check statuses
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+)
+
+// Check is the result of one record check of a domain
+type Check struct {
+	Domain string   `json:"domain"`
+	Name   string   `json:"name"`
+	Status string   `json:"status"`
+	Found  []string `json:"found"`
+	Detail string   `json:"detail,omitempty"`
+	// Fix lists the records to add when the check fails
+	Fix []Record `json:"fix,omitempty"`
+}
+
+// CheckDomain compares the MX, SPF, DKIM and DMARC records of a custom
+// domain with those the instance expects
+func CheckDomain(ctx context.Context, r Resolver, inst Instance, domain string) []Check {
+	domain = Fqdn(domain)
+	expected := inst.Expected(domain)
+
+	checks := []Check{
+		checkMX(ctx, r, domain, filterRecords(expected, TypeMX, domain)),
+		checkSPF(ctx, r, inst, domain),
+	}
+	for _, selector := range DKIMSelectors {
+		checks = append(checks, checkCNAME(ctx, r, "DKIM "+selector, filterRecords(expected, TypeCNAME, selector+"._domainkey."+domain)[0]))
+	}
+	checks = append(checks, checkDMARC(ctx, r, domain, filterRecords(expected, TypeTXT, "_dmarc."+domain)[0]))
+
+	for i := range checks {
+		checks[i].Domain = strings.TrimSuffix(domain, ".")
+	}
+
+	return checks
+}
+
+func checkMX(ctx context.Context, r Resolver, domain string, want []Record) Check {
+	check := Check{Name: "MX"}
+
+	records, err := r.LookupMX(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return failed(check, err)
+	}
+
+	var found []string
+	for _, mx := range records {
+		found = append(found, Fqdn(mx.Host))
+		check.Found = append(check.Found, fmt.Sprintf("%d %s", mx.Pref, Fqdn(mx.Host)))
+	}
+
+	var wanted []string
+	for _, record := range want {
+		wanted = append(wanted, record.Value)
+		if !slices.Contains(found, record.Value) {
+			check.Fix = append(check.Fix, record)
+		}
+	}
+
+	var extra []string
+	for _, host := range found {
+		if !slices.Contains(wanted, host) {
+			extra = append(extra, host)
+		}
+	}
+
+	switch {
+	case len(check.Fix) > 0:
+		check.Status = StatusFail
+		check.Detail = "missing mail servers"
+	case len(extra) > 0:
+		// Another mail server would receive part of the emails
+		check.Status = StatusFail
+		check.Detail = "remove " + strings.Join(extra, ", ")
+	default:
+		check.Status = StatusPass
+	}
+
+	return check
+}
+
+func checkSPF(ctx context.Context, r Resolver, inst Instance, domain string) Check {
+	check := Check{Name: "SPF"}
+
+	txts, err := r.LookupTXT(ctx, domain)
+	if err != nil && !isNotFound(err) {
+		return failed(check, err)
+	}
+
+	var spf []string
+	for _, txt := range txts {
+		if strings.HasPrefix(strings.ToLower(txt), "v=spf1") {
+			spf = append(spf, txt)
+		}
+	}
+	check.Found = spf
+
+	include := "include:" + strings.ToLower(inst.SPFInclude)
+	record := Record{Name: domain, Type: TypeTXT, Value: inst.SPF()}
+
+	switch len(spf) {
+	case 0:
+		check.Status = StatusFail
+		check.Detail = "no SPF record"
+		check.Fix = []Record{record}
+	case 1:
+		if slices.Contains(strings.Fields(strings.ToLower(spf[0])), include) {
+			check.Status = StatusPass
+			break
+		}
+		// Keep the other senders of the domain in the record
+		fields := strings.Fields(spf[0])
+		record.Value = strings.Join(append([]string{fields[0], include}, fields[1:]...), " ")
+		check.Status = StatusFail
+		check.Detail = "SPF record does not include " + inst.SPFInclude
+		check.Fix = []Record{record}
+	default:
+		check.Status = StatusFail
+		check.Detail = "several SPF records, merge them into one"
+		check.Fix = []Record{record}
+	}
+
+	return check
+}
+
+func checkCNAME(ctx context.Context, r Resolver, name string, want Record) Check {
+	check := Check{Name: name}
+
+	cname, err := r.LookupCNAME(ctx, want.Name)
+	if err != nil && !isNotFound(err) {
+		return failed(check, err)
+	}
+	// The resolver returns the name itself when there is no CNAME
+	if cname != "" && Fqdn(cname) != want.Name {
+		check.Found = []string{Fqdn(cname)}
+	}
+
+	if len(check.Found) == 1 && check.Found[0] == want.Value {
+		check.Status = StatusPass
+		return check
+	}
+
+	check.Status = StatusFail
+	check.Detail = "no CNAME"
+	if len(check.Found) > 0 {
+		check.Detail = "wrong CNAME target"
+	}
+	check.Fix = []Record{want}
+	return check
+}
+
+func checkDMARC(ctx context.Context, r Resolver, domain string, want Record) Check {
+	check := Check{Name: "DMARC"}
+
+	txts, err := r.LookupTXT(ctx, want.Name)
+	if err != nil && !isNotFound(err) {
+		return failed(check, err)
+	}
+
+	for _, txt := range txts {
+		if strings.HasPrefix(strings.ToLower(txt), "v=dmarc1") {
+			check.Found = append(check.Found, txt)
+		}
+	}
+
+	if len(check.Found) == 1 {
+		switch dmarcTag(check.Found[0], "p") {
+		case "quarantine", "reject":
+			check.Status = StatusPass
+			return check
+		}
+		check.Detail = "policy should be quarantine or reject"
+	} else if len(check.Found) > 1 {
+		check.Detail = "several DMARC records"
+	} else {
+		check.Detail = "no DMARC record"
+	}
+
+	check.Status = StatusFail
+	check.Fix = []Record{want}
+	return check
+}
+
+// dmarcTag returns the value of a tag of a DMARC record
+func dmarcTag(record, tag string) string {
+	for _, part := range strings.Split(record, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if ok && strings.EqualFold(strings.TrimSpace(key), tag) {
+			return strings.ToLower(strings.TrimSpace(value))
+		}
+	}
+	return ""
+}
+
+func failed(check Check, err error) Check {
+	check.Status = StatusFail
+	check.Detail = err.Error()
+	return check
+}
+
+func filterRecords(records []Record, recordType, name string) []Record {
+	var result []Record
+	for _, record := range records {
+		if record.Type == recordType && record.Name == name {
+			result = append(result, record)
+		}
+	}
+	return result
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
@@ -0,0 +1,149 @@
+package dnsrecords
+
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+// fakeResolver serves records from maps, like a local DNS stand-in
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	txt   map[string][]string
+	cname map[string]string
+}
+
+func notFound(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r *fakeResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+	return nil, notFound(name)
+}
+
+func (r *fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if records, ok := r.txt[name]; ok {
+		return records, nil
+	}
+	return nil, notFound(name)
+}
+
+func (r *fakeResolver) LookupCNAME(_ context.Context, name string) (string, error) {
+	if target, ok := r.cname[name]; ok {
+		return target, nil
+	}
+	// Like net.Resolver, a name without CNAME resolves to itself
+	return name, nil
+}
+
+func healthyResolver() *fakeResolver {
+	return &fakeResolver{
+		mx: map[string][]*net.MX{
+			"example.com.": {
+				{Host: "mx1.simplelogin.co.", Pref: 10},
+				{Host: "mx2.simplelogin.co.", Pref: 20},
+			},
+		},
+		txt: map[string][]string{
+			"example.com.":        {"google-site-verification=abc", "v=spf1 include:simplelogin.co ~all"},
+			"_dmarc.example.com.": {"v=DMARC1; p=reject"},
+		},
+		cname: map[string]string{
+			"dkim._domainkey.example.com.":   "dkim._domainkey.simplelogin.co.",
+			"dkim02._domainkey.example.com.": "dkim02._domainkey.simplelogin.co.",
+			"dkim03._domainkey.example.com.": "dkim03._domainkey.simplelogin.co.",
+		},
+	}
+}
+
+func statuses(checks []Check) map[string]Check {
+	result := map[string]Check{}
+	for _, check := range checks {
+		result[check.Name] = check
+	}
+	return result
+}
+
+func TestCheckDomain_Healthy(t *testing.T) {
+	checks := CheckDomain(context.Background(), healthyResolver(), Hosted, "Example.com")
+
+	if len(checks) != 6 {
+		t.Fatalf("CheckDomain() returned %d checks, want 6", len(checks))
+	}
+	for _, check := range checks {
+		if check.Status != StatusPass {
+			t.Errorf("%s = %s (%s), want pass", check.Name, check.Status, check.Detail)
+		}
+		if check.Domain != "example.com" {
+			t.Errorf("%s domain = %s, want example.com", check.Name, check.Domain)
+		}
+	}
+}
+
+func TestCheckDomain_Broken(t *testing.T) {
+	r := healthyResolver()
+	r.mx["example.com."] = []*net.MX{{Host: "mx1.simplelogin.co.", Pref: 10}, {Host: "mx.other.net.", Pref: 5}}
+	r.txt["example.com."] = []string{"v=spf1 include:_spf.google.com ~all"}
+	r.txt["_dmarc.example.com."] = []string{"v=DMARC1; p=none"}
+	delete(r.cname, "dkim02._domainkey.example.com.")
+	r.cname["dkim03._domainkey.example.com."] = "dkim03._domainkey.other.net."
+
+	checks := statuses(CheckDomain(context.Background(), r, Hosted, "example.com"))
+
+	tests := []struct {
+		name   string
+		status string
+		fix    string
+	}{
+		{name: "MX", status: StatusFail, fix: "example.com. IN MX 20 mx2.simplelogin.co."},
+		{name: "SPF", status: StatusFail, fix: `example.com. IN TXT "v=spf1 include:simplelogin.co include:_spf.google.com ~all"`},
+		{name: "DKIM dkim", status: StatusPass},
+		{name: "DKIM dkim02", status: StatusFail, fix: "dkim02._domainkey.example.com. IN CNAME dkim02._domainkey.simplelogin.co."},
+		{name: "DKIM dkim03", status: StatusFail, fix: "dkim03._domainkey.example.com. IN CNAME dkim03._domainkey.simplelogin.co."},
+		{name: "DMARC", status: StatusFail, fix: `_dmarc.example.com. IN TXT "` + DMARCPolicy + `"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check, ok := checks[tt.name]
+			if !ok {
+				t.Fatalf("no %s check", tt.name)
+			}
+			if check.Status != tt.status {
+				t.Errorf("status = %s, want %s", check.Status, tt.status)
+			}
+			if tt.fix == "" {
+				if len(check.Fix) != 0 {
+					t.Errorf("fix = %v, want none", check.Fix)
+				}
+				return
+			}
+			if len(check.Fix) != 1 || check.Fix[0].String() != tt.fix {
+				t.Errorf("fix = %v, want %s", check.Fix, tt.fix)
+			}
+		})
+	}
+}
+
+func TestCheckDomain_ExtraMX(t *testing.T) {
+	r := healthyResolver()
+	r.mx["example.com."] = append(r.mx["example.com."], &net.MX{Host: "mx.other.net.", Pref: 30})
+
+	check := statuses(CheckDomain(context.Background(), r, Hosted, "example.com"))["MX"]
+	if check.Status != StatusFail || check.Detail != "remove mx.other.net." {
+		t.Errorf("MX = %s (%s), want fail asking to remove mx.other.net.", check.Status, check.Detail)
+	}
+}
+
+func TestCheckDomain_Missing(t *testing.T) {
+	r := &fakeResolver{}
+
+	for _, check := range CheckDomain(context.Background(), r, Hosted, "example.com") {
+		if check.Status != StatusFail || len(check.Fix) == 0 {
+			t.Errorf("%s = %s with fix %v, want fail with a fix", check.Name, check.Status, check.Fix)
+		}
+	}
+}
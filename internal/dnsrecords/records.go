@@ -0,0 +1,138 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+)
+
+// Record types
+const (
+	TypeMX    = "MX"
+	TypeTXT   = "TXT"
+	TypeCNAME = "CNAME"
+)
+
+// DMARCPolicy is the DMARC record SimpleLogin recommends
+const DMARCPolicy = "v=DMARC1; p=quarantine; pct=100; adkim=s; aspf=s"
+
+// DKIMSelectors are the selectors SimpleLogin signs with; each one is a
+// CNAME to the key published by the instance so that keys can be rotated
+var DKIMSelectors = []string{"dkim", "dkim02", "dkim03"}
+
+// MX is a mail server of an instance
+type MX struct {
+	Host     string `json:"host"`
+	Priority int    `json:"priority"`
+}
+
+// Instance describes the mail setup of a SimpleLogin instance
+type Instance struct {
+	MX []MX `json:"mx"`
+	// SPFInclude is the domain included in the SPF record of custom domains
+	SPFInclude string `json:"spf_include"`
+	// DKIMDomain publishes the DKIM keys the custom domain CNAMEs point to
+	DKIMDomain string `json:"dkim_domain"`
+}
+
+// Hosted is the instance at app.simplelogin.io
+var Hosted = Instance{
+	MX: []MX{
+		{Host: "mx1.simplelogin.co", Priority: 10},
+		{Host: "mx2.simplelogin.co", Priority: 20},
+	},
+	SPFInclude: "simplelogin.co",
+	DKIMDomain: "simplelogin.co",
+}
+
+// ForAPIURL returns the instance serving an API URL. Self-hosted instances
+// follow the documented setup: the app host receives mail and its parent
+// domain, e.g. example.com for app.example.com, publishes SPF and DKIM.
+func ForAPIURL(apiURL string) (Instance, error) {
+	u, err := url.Parse(apiURL)
+	if err != nil {
+		return Instance{}, err
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return Instance{}, fmt.Errorf("invalid API URL %q", apiURL)
+	}
+	if host == "simplelogin.io" || strings.HasSuffix(host, ".simplelogin.io") {
+		return Hosted, nil
+	}
+	if net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+		return Instance{}, fmt.Errorf("cannot derive the mail setup of the instance from %s", host)
+	}
+
+	domain := host
+	if labels := strings.Split(host, "."); len(labels) > 2 {
+		domain = strings.Join(labels[1:], ".")
+	}
+
+	return Instance{
+		MX:         []MX{{Host: host, Priority: 10}},
+		SPFInclude: domain,
+		DKIMDomain: domain,
+	}, nil
+}
+
+// Record is a DNS record; Name and CNAME or MX targets are fully qualified
+// and end with a dot
+type Record struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Priority int    `json:"priority,omitempty"`
+	Value    string `json:"value"`
+}
+
+// String formats the record as a zone file line
+func (r Record) String() string {
+	switch r.Type {
+	case TypeMX:
+		return fmt.Sprintf("%s IN MX %d %s", r.Name, r.Priority, r.Value)
+	case TypeTXT:
+		return fmt.Sprintf("%s IN TXT %q", r.Name, r.Value)
+	default:
+		return fmt.Sprintf("%s IN %s %s", r.Name, r.Type, r.Value)
+	}
+}
+
+// SPF returns the SPF record value of a custom domain
+func (inst Instance) SPF() string {
+	return fmt.Sprintf("v=spf1 include:%s ~all", inst.SPFInclude)
+}
+
+// Expected returns the records a custom domain needs on the instance
+func (inst Instance) Expected(domain string) []Record {
+	domain = Fqdn(domain)
+
+	var records []Record
+	for _, mx := range inst.MX {
+		records = append(records, Record{Name: domain, Type: TypeMX, Priority: mx.Priority, Value: Fqdn(mx.Host)})
+	}
+
+	records = append(records, Record{Name: domain, Type: TypeTXT, Value: inst.SPF()})
+
+	for _, selector := range DKIMSelectors {
+		records = append(records, Record{
+			Name:  selector + "._domainkey." + domain,
+			Type:  TypeCNAME,
+			Value: Fqdn(selector + "._domainkey." + inst.DKIMDomain),
+		})
+	}
+
+	records = append(records, Record{Name: "_dmarc." + domain, Type: TypeTXT, Value: DMARCPolicy})
+
+	return records
+}
+
+// Fqdn returns name in lower case with a trailing dot
+func Fqdn(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
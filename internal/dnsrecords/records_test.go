@@ -0,0 +1,64 @@
+package dnsrecords
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestForAPIURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    Instance
+		wantErr bool
+	}{
+		{url: "https://app.simplelogin.io/api", want: Hosted},
+		{url: "https://app.example.org/api", want: Instance{
+			MX:         []MX{{Host: "app.example.org", Priority: 10}},
+			SPFInclude: "example.org",
+			DKIMDomain: "example.org",
+		}},
+		{url: "https://example.org/api", want: Instance{
+			MX:         []MX{{Host: "example.org", Priority: 10}},
+			SPFInclude: "example.org",
+			DKIMDomain: "example.org",
+		}},
+		{url: "not a url", wantErr: true},
+		{url: "http://127.0.0.1:7777/api", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := ForAPIURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ForAPIURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !slices.Equal(got.MX, tt.want.MX) || got.SPFInclude != tt.want.SPFInclude || got.DKIMDomain != tt.want.DKIMDomain {
+				t.Errorf("ForAPIURL() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInstance_Expected(t *testing.T) {
+	want := []string{
+		"example.com. IN MX 10 mx1.simplelogin.co.",
+		"example.com. IN MX 20 mx2.simplelogin.co.",
+		`example.com. IN TXT "v=spf1 include:simplelogin.co ~all"`,
+		"dkim._domainkey.example.com. IN CNAME dkim._domainkey.simplelogin.co.",
+		"dkim02._domainkey.example.com. IN CNAME dkim02._domainkey.simplelogin.co.",
+		"dkim03._domainkey.example.com. IN CNAME dkim03._domainkey.simplelogin.co.",
+		`_dmarc.example.com. IN TXT "v=DMARC1; p=quarantine; pct=100; adkim=s; aspf=s"`,
+	}
+
+	var got []string
+	for _, record := range Hosted.Expected("example.com") {
+		got = append(got, record.String())
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("Expected() =\n%v\nwant\n%v", got, want)
+	}
+}
@@ -0,0 +1,215 @@
+package dnsrecords
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Zone snippet formats
+const (
+	FormatBind      = "bind"
+	FormatTerraform = "terraform"
+	FormatOctoDNS   = "octodns"
+)
+
+// Formats lists the supported zone snippet formats
+var Formats = []string{FormatBind, FormatTerraform, FormatOctoDNS}
+
+// DefaultTTL is the TTL of the generated records
+const DefaultTTL = 3600
+
+// recordSet groups the records sharing a name and type, as DNS providers do
+type recordSet struct {
+	Name    string
+	Type    string
+	Records []Record
+}
+
+// WriteZone writes records of a domain in a zone snippet format
+func WriteZone(w io.Writer, format, domain string, records []Record, ttl int) error {
+	domain = Fqdn(domain)
+
+	switch format {
+	case FormatBind:
+		return writeBind(w, domain, records, ttl)
+	case FormatTerraform:
+		return writeTerraform(w, domain, records, ttl)
+	case FormatOctoDNS:
+		return writeOctoDNS(w, domain, records, ttl)
+	default:
+		return fmt.Errorf("unsupported format %q, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+func writeBind(w io.Writer, domain string, records []Record, ttl int) error {
+	fmt.Fprintf(w, "; SimpleLogin records for %s\n", domain)
+	fmt.Fprintf(w, "$ORIGIN %s\n", domain)
+
+	for _, record := range records {
+		name := relativeName(record.Name, domain)
+		if name == "" {
+			name = "@"
+		}
+
+		var value string
+		switch record.Type {
+		case TypeMX:
+			value = fmt.Sprintf("%d %s", record.Priority, record.Value)
+		case TypeTXT:
+			value = quoteTXT(record.Value)
+		default:
+			value = record.Value
+		}
+
+		if _, err := fmt.Fprintf(w, "%-30s %d IN %-5s %s\n", name, ttl, record.Type, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeTerraform writes aws_route53_record resources, one per record set
+func writeTerraform(w io.Writer, domain string, records []Record, ttl int) error {
+	fmt.Fprintf(w, "# SimpleLogin records for %s\n", strings.TrimSuffix(domain, "."))
+	fmt.Fprintln(w, "# Merge the SPF record with an existing one instead of adding a second record.")
+
+	for _, set := range recordSets(records) {
+		values := make([]string, len(set.Records))
+		for i, record := range set.Records {
+			if record.Type == TypeMX {
+				values[i] = fmt.Sprintf("%q", fmt.Sprintf("%d %s", record.Priority, record.Value))
+			} else {
+				values[i] = fmt.Sprintf("%q", record.Value)
+			}
+		}
+
+		if _, err := fmt.Fprintf(w, `
+resource "aws_route53_record" %q {
+  zone_id = var.zone_id
+  name    = %q
+  type    = %q
+  ttl     = %d
+  records = [%s]
+}
+`, resourceName(set), strings.TrimSuffix(set.Name, "."), set.Type, ttl, strings.Join(values, ", ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeOctoDNS writes an octoDNS zone file, names relative to the zone
+func writeOctoDNS(w io.Writer, domain string, records []Record, ttl int) error {
+	fmt.Fprintln(w, "---")
+	fmt.Fprintf(w, "# SimpleLogin records for %s\n", domain)
+
+	// octoDNS keys records by name, with a list when a name has several types
+	var names []string
+	sets := map[string][]recordSet{}
+	for _, set := range recordSets(records) {
+		name := relativeName(set.Name, domain)
+		if _, ok := sets[name]; !ok {
+			names = append(names, name)
+		}
+		sets[name] = append(sets[name], set)
+	}
+
+	for _, name := range names {
+		fmt.Fprintf(w, "%s:\n", yamlQuote(name))
+
+		indent := "  "
+		list := len(sets[name]) > 1
+		for _, set := range sets[name] {
+			prefix := indent
+			if list {
+				prefix = indent + "- "
+				fmt.Fprint(w, prefix)
+				prefix = indent + "  "
+			} else {
+				fmt.Fprint(w, prefix)
+			}
+
+			fmt.Fprintf(w, "type: %s\n", set.Type)
+			fmt.Fprintf(w, "%sttl: %d\n", prefix, ttl)
+
+			switch {
+			case set.Type == TypeMX:
+				fmt.Fprintf(w, "%svalues:\n", prefix)
+				for _, record := range set.Records {
+					fmt.Fprintf(w, "%s- exchange: %s\n", prefix, record.Value)
+					fmt.Fprintf(w, "%s  preference: %d\n", prefix, record.Priority)
+				}
+			case len(set.Records) == 1:
+				fmt.Fprintf(w, "%svalue: %s\n", prefix, octoDNSValue(set.Records[0]))
+			default:
+				fmt.Fprintf(w, "%svalues:\n", prefix)
+				for _, record := range set.Records {
+					fmt.Fprintf(w, "%s- %s\n", prefix, octoDNSValue(record))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func recordSets(records []Record) []recordSet {
+	var sets []recordSet
+	for _, record := range records {
+		if n := len(sets); n > 0 && sets[n-1].Name == record.Name && sets[n-1].Type == record.Type {
+			sets[n-1].Records = append(sets[n-1].Records, record)
+			continue
+		}
+		sets = append(sets, recordSet{Name: record.Name, Type: record.Type, Records: []Record{record}})
+	}
+	return sets
+}
+
+// relativeName returns name relative to the zone, empty for the apex
+func relativeName(name, domain string) string {
+	if name == domain {
+		return ""
+	}
+	return strings.TrimSuffix(name, "."+domain)
+}
+
+var nonIdentifier = regexp.MustCompile(`[^a-z0-9]+`)
+
+// resourceName returns a Terraform identifier such as example_com_mx
+func resourceName(set recordSet) string {
+	name := nonIdentifier.ReplaceAllString(strings.ToLower(strings.TrimSuffix(set.Name, ".")), "_")
+	return strings.Trim(name, "_") + "_" + strings.ToLower(set.Type)
+}
+
+// quoteTXT quotes a TXT value for a zone file, splitting it in 255 byte strings
+func quoteTXT(value string) string {
+	var parts []string
+	for len(value) > 255 {
+		parts = append(parts, value[:255])
+		value = value[255:]
+	}
+	parts = append(parts, value)
+
+	for i, part := range parts {
+		part = strings.ReplaceAll(part, `\`, `\\`)
+		parts[i] = `"` + strings.ReplaceAll(part, `"`, `\"`) + `"`
+	}
+	return strings.Join(parts, " ")
+}
+
+// octoDNSValue formats a record value; octoDNS requires escaped semicolons in TXT values
+func octoDNSValue(record Record) string {
+	if record.Type == TypeTXT {
+		return yamlQuote(strings.ReplaceAll(record.Value, ";", `\;`))
+	}
+	return yamlQuote(record.Value)
+}
+
+// yamlQuote returns a single-quoted YAML string
+func yamlQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,95 @@
+package dnsrecords
+
+import (
+	"strings"
+	"testing"
+)
+
+func writeZone(t *testing.T, format string) string {
+	t.Helper()
+
+	var b strings.Builder
+	if err := WriteZone(&b, format, "example.com", Hosted.Expected("example.com"), 300); err != nil {
+		t.Fatalf("WriteZone(%s) error = %v", format, err)
+	}
+	return b.String()
+}
+
+func TestWriteZoneBind(t *testing.T) {
+	out := writeZone(t, FormatBind)
+
+	for _, want := range []string{
+		"$ORIGIN example.com.\n",
+		"@                              300 IN MX    10 mx1.simplelogin.co.\n",
+		"@                              300 IN TXT   \"v=spf1 include:simplelogin.co ~all\"\n",
+		"dkim02._domainkey              300 IN CNAME dkim02._domainkey.simplelogin.co.\n",
+		"_dmarc                         300 IN TXT   \"" + DMARCPolicy + "\"\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("bind output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteZoneTerraform(t *testing.T) {
+	out := writeZone(t, FormatTerraform)
+
+	if got := strings.Count(out, `resource "aws_route53_record"`); got != 6 {
+		t.Errorf("got %d resources, want 6 (MX set, SPF, 3 DKIM, DMARC):\n%s", got, out)
+	}
+	for _, want := range []string{
+		`resource "aws_route53_record" "example_com_mx" {`,
+		`  records = ["10 mx1.simplelogin.co.", "20 mx2.simplelogin.co."]`,
+		`resource "aws_route53_record" "dmarc_example_com_txt" {`,
+		`  name    = "_dmarc.example.com"`,
+		`  ttl     = 300`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("terraform output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestWriteZoneOctoDNS(t *testing.T) {
+	out := writeZone(t, FormatOctoDNS)
+
+	want := `'':
+  - type: MX
+    ttl: 300
+    values:
+    - exchange: mx1.simplelogin.co.
+      preference: 10
+    - exchange: mx2.simplelogin.co.
+      preference: 20
+  - type: TXT
+    ttl: 300
+    value: 'v=spf1 include:simplelogin.co ~all'
+'dkim._domainkey':
+  type: CNAME
+  ttl: 300
+  value: 'dkim._domainkey.simplelogin.co.'
+`
+	if !strings.Contains(out, want) {
+		t.Errorf("octodns output missing apex and dkim records:\n%s", out)
+	}
+	if !strings.Contains(out, `value: 'v=DMARC1\; p=quarantine\; pct=100\; adkim=s\; aspf=s'`) {
+		t.Errorf("octodns output must escape semicolons in TXT values:\n%s", out)
+	}
+}
+
+func TestWriteZoneUnknownFormat(t *testing.T) {
+	var b strings.Builder
+	if err := WriteZone(&b, "yaml", "example.com", nil, 300); err == nil {
+		t.Error("WriteZone(yaml) error = nil, want error")
+	}
+}
+
+func TestQuoteTXT(t *testing.T) {
+	long := strings.Repeat("a", 300)
+	if got, want := quoteTXT(long), `"`+strings.Repeat("a", 255)+`" "`+strings.Repeat("a", 45)+`"`; got != want {
+		t.Errorf("quoteTXT(300 bytes) = %q, want %q", got, want)
+	}
+	if got, want := quoteTXT(`a "b"`), `"a \"b\""`; got != want {
+		t.Errorf("quoteTXT = %q, want %q", got, want)
+	}
+}
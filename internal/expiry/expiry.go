@@ -0,0 +1,70 @@
+package expiry
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juli3nk/simplelogin-cli/internal/notemeta"
+)
+
+// NoteKey is the note metadata key holding the alias expiry date
+const NoteKey = "expires"
+
+const dateLayout = "2006-01-02"
+
+// Parse resolves an expiry given as a duration relative to now (30d, 2w,
+// 12h, or any Go duration) or as an absolute date (2026-12-31 or RFC 3339)
+func Parse(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty expiry")
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.ParseInLocation(dateLayout, value, time.Local); err == nil {
+		return t.UTC(), nil
+	}
+
+	unit := value[len(value)-1]
+	if unit == 'd' || unit == 'w' {
+		n, err := strconv.Atoi(value[:len(value)-1])
+		if err != nil || n <= 0 {
+			return time.Time{}, fmt.Errorf("invalid expiry %q", value)
+		}
+		if unit == 'w' {
+			n *= 7
+		}
+		return now.AddDate(0, 0, n).UTC(), nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid expiry %q, expected e.g. 30d, 2w, 12h or 2026-12-31", value)
+	}
+
+	return now.Add(d).UTC(), nil
+}
+
+// Get returns the expiry recorded in a note
+func Get(note string) (time.Time, bool, error) {
+	value, ok := notemeta.Get(note, NoteKey)
+	if !ok {
+		return time.Time{}, false, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, true, fmt.Errorf("invalid %s metadata %q", NoteKey, value)
+	}
+
+	return t, true, nil
+}
+
+// Set records the expiry in a note
+func Set(note string, t time.Time) string {
+	return notemeta.Set(note, NoteKey, t.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,54 @@
+package expiry
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value   string
+		want    time.Time
+		wantErr bool
+	}{
+		{value: "30d", want: time.Date(2026, 3, 31, 10, 0, 0, 0, time.UTC)},
+		{value: "2w", want: time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)},
+		{value: "12h", want: time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)},
+		{value: "2026-06-01T00:00:00Z", want: time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "0d", wantErr: true},
+		{value: "-1h", wantErr: true},
+		{value: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := Parse(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !got.Equal(tt.want) {
+				t.Errorf("Parse() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetGet(t *testing.T) {
+	expires := time.Date(2026, 3, 31, 10, 0, 0, 0, time.UTC)
+
+	note := Set("Conference badge", expires)
+	if note != "Conference badge\n#expires: 2026-03-31T10:00:00Z" {
+		t.Errorf("Set() = %q", note)
+	}
+
+	got, ok, err := Get(note)
+	if err != nil || !ok || !got.Equal(expires) {
+		t.Errorf("Get() = %s, %t, %v", got, ok, err)
+	}
+
+	if _, ok, _ := Get("no metadata"); ok {
+		t.Error("Get() found an expiry in a plain note")
+	}
+}
@@ -0,0 +1,263 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Supported export formats
+const (
+	FormatAddy           = "addy"
+	FormatRelay          = "relay"
+	FormatDuckDuckGo     = "duckduckgo"
+	FormatSimpleLoginCSV = "simplelogin-csv"
+)
+
+// Formats lists the supported export formats
+var Formats = []string{FormatAddy, FormatRelay, FormatDuckDuckGo, FormatSimpleLoginCSV}
+
+// Entry represents an alias read from another provider's export
+type Entry struct {
+	Address     string `json:"address"`
+	LocalPart   string `json:"local_part"`
+	Description string `json:"description,omitempty"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// Parse reads the aliases of an export in the given format
+func Parse(format string, r io.Reader) ([]Entry, error) {
+	switch format {
+	case FormatAddy:
+		return parseAddy(r)
+	case FormatRelay:
+		return parseRelay(r)
+	case FormatDuckDuckGo:
+		return parseDuckDuckGo(r)
+	case FormatSimpleLoginCSV:
+		return parseSimpleLoginCSV(r)
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// parseAddy reads the CSV export of addy.io (Settings > Export aliases)
+func parseAddy(r io.Reader) ([]Entry, error) {
+	rows, err := readCSV(r, "email")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, row := range rows {
+		if row["deleted_at"] != "" {
+			continue
+		}
+
+		entry, err := newEntry(row["email"], row["description"], row["active"])
+		if err != nil {
+			return nil, err
+		}
+		if localPart := row["local_part"]; localPart != "" {
+			entry.LocalPart = strings.ToLower(localPart)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// relayAddress is an address as returned by the Firefox Relay API
+type relayAddress struct {
+	FullAddress  string `json:"full_address"`
+	Address      string `json:"address"`
+	Domain       string `json:"domain"`
+	Description  string `json:"description"`
+	GeneratedFor string `json:"generated_for"`
+	Enabled      *bool  `json:"enabled"`
+}
+
+// parseRelay reads Firefox Relay masks, either the JSON returned by
+// /api/v1/relayaddresses/ or a CSV with an address and description column
+func parseRelay(r io.Reader) ([]Entry, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("[")) {
+		rows, err := readCSV(bytes.NewReader(data), "full_address", "address", "mask")
+		if err != nil {
+			return nil, err
+		}
+
+		var entries []Entry
+		for _, row := range rows {
+			address := firstOf(row, "full_address", "address", "mask")
+			entry, err := newEntry(address, firstOf(row, "description", "label"), row["enabled"])
+			if err != nil {
+				return nil, err
+			}
+			entries = append(entries, entry)
+		}
+		return entries, nil
+	}
+
+	var masks []relayAddress
+	if err := json.Unmarshal(data, &masks); err != nil {
+		return nil, fmt.Errorf("invalid Relay export: %w", err)
+	}
+
+	var entries []Entry
+	for _, mask := range masks {
+		address := mask.FullAddress
+		if address == "" && mask.Address != "" && mask.Domain != "" {
+			address = mask.Address + "@" + mask.Domain
+		}
+
+		description := mask.Description
+		if description == "" {
+			description = mask.GeneratedFor
+		}
+
+		entry, err := newEntry(address, description, "")
+		if err != nil {
+			return nil, err
+		}
+		if mask.Enabled != nil {
+			entry.Enabled = *mask.Enabled
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// parseDuckDuckGo reads a plain list of Duck addresses, one per line,
+// optionally followed by a comma and a description. DuckDuckGo has no export
+// so the list is usually copied by hand.
+func parseDuckDuckGo(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		address, description, _ := strings.Cut(line, ",")
+		entry, err := newEntry(address, description, "")
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, scanner.Err()
+}
+
+// parseSimpleLoginCSV reads the CSV export of SimpleLogin (Settings > Export data)
+func parseSimpleLoginCSV(r io.Reader) ([]Entry, error) {
+	rows, err := readCSV(r, "alias")
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, row := range rows {
+		entry, err := newEntry(row["alias"], row["note"], row["enabled"])
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func newEntry(address, description, enabled string) (Entry, error) {
+	address = strings.ToLower(strings.TrimSpace(address))
+
+	localPart, domain, ok := strings.Cut(address, "@")
+	if !ok || localPart == "" || domain == "" {
+		return Entry{}, fmt.Errorf("invalid address %q", address)
+	}
+
+	return Entry{
+		Address:     address,
+		LocalPart:   localPart,
+		Description: strings.TrimSpace(description),
+		Enabled:     parseBool(enabled),
+	}, nil
+}
+
+// readCSV reads a CSV file with a header into one map per row; one of the
+// required columns must be present
+func readCSV(r io.Reader, required ...string) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := make([]string, len(records[0]))
+	for i, name := range records[0] {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	}
+
+	found := false
+	for _, name := range required {
+		for _, column := range header {
+			found = found || column == name
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("missing column %s in CSV header", strings.Join(required, " or "))
+	}
+
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(header))
+		for i, value := range record {
+			if i < len(header) {
+				row[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func firstOf(row map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if value := row[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseBool treats empty values as enabled, exports only mark disabled aliases explicitly
+func parseBool(value string) bool {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "0", "false", "no", "off", "disabled", "inactive":
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,88 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		input  string
+		want   []Entry
+	}{
+		{
+			name:   "addy",
+			format: FormatAddy,
+			input: "id,user_id,local_part,extension,domain,email,active,description,deleted_at\n" +
+				"1,2,Shop,,anonaddy.me,shop@anonaddy.me,1,Online shop,\n" +
+				"2,2,old,,anonaddy.me,old@anonaddy.me,0,,2025-01-01\n" +
+				"3,2,news,,anonaddy.me,news@anonaddy.me,0,,\n",
+			want: []Entry{
+				{Address: "shop@anonaddy.me", LocalPart: "shop", Description: "Online shop", Enabled: true},
+				{Address: "news@anonaddy.me", LocalPart: "news", Enabled: false},
+			},
+		},
+		{
+			name:   "relay json",
+			format: FormatRelay,
+			input: `[{"full_address":"abc123@mozmail.com","description":"","generated_for":"example.com","enabled":true},
+				{"address":"xyz","domain":"mozmail.com","description":"Forum","enabled":false}]`,
+			want: []Entry{
+				{Address: "abc123@mozmail.com", LocalPart: "abc123", Description: "example.com", Enabled: true},
+				{Address: "xyz@mozmail.com", LocalPart: "xyz", Description: "Forum", Enabled: false},
+			},
+		},
+		{
+			name:   "relay csv",
+			format: FormatRelay,
+			input:  "Mask,Label\nabc123@mozmail.com,Shop\n",
+			want: []Entry{
+				{Address: "abc123@mozmail.com", LocalPart: "abc123", Description: "Shop", Enabled: true},
+			},
+		},
+		{
+			name:   "duckduckgo",
+			format: FormatDuckDuckGo,
+			input:  "# my duck addresses\nquick-fox-jumps@duck.com, Newsletter\n\nlazy-dog@duck.com\n",
+			want: []Entry{
+				{Address: "quick-fox-jumps@duck.com", LocalPart: "quick-fox-jumps", Description: "Newsletter", Enabled: true},
+				{Address: "lazy-dog@duck.com", LocalPart: "lazy-dog", Enabled: true},
+			},
+		},
+		{
+			name:   "simplelogin csv",
+			format: FormatSimpleLoginCSV,
+			input:  "alias,note,enabled,mailboxes\nshop@example.com,Online shop,True,me@example.org\n",
+			want: []Entry{
+				{Address: "shop@example.com", LocalPart: "shop", Description: "Online shop", Enabled: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.format, strings.NewReader(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	if _, err := Parse("gmail", strings.NewReader("")); err == nil {
+		t.Error("Parse() accepted an unknown format")
+	}
+	if _, err := Parse(FormatSimpleLoginCSV, strings.NewReader("email,note\na@b.c,x\n")); err == nil {
+		t.Error("Parse() accepted a CSV without alias column")
+	}
+	if _, err := Parse(FormatDuckDuckGo, strings.NewReader("not-an-address\n")); err == nil {
+		t.Error("Parse() accepted an invalid address")
+	}
+}
@@ -0,0 +1,215 @@
+package journal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+)
+
+// Actions recorded in the journal
+const (
+	ActionAliasToggle  = "alias-toggle"
+	ActionAliasUpdate  = "alias-update"
+	ActionContactBlock = "contact-block"
+)
+
+// MaxEntries is the number of entries kept, older ones are dropped
+const MaxEntries = 100
+
+// State holds the fields of an object before a change; unset fields were not changed
+type State struct {
+	Enabled    *bool   `json:"enabled,omitempty"`
+	Blocked    *bool   `json:"blocked,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	Note       *string `json:"note,omitempty"`
+	Pinned     *bool   `json:"pinned,omitempty"`
+	MailboxIDs []int   `json:"mailbox_ids,omitempty"`
+}
+
+// Entry is a change that can be reverted
+type Entry struct {
+	ID        int       `json:"id"`
+	Time      time.Time `json:"time"`
+	Action    string    `json:"action"`
+	AliasID   int       `json:"alias_id,omitempty"`
+	ContactID int       `json:"contact_id,omitempty"`
+	Subject   string    `json:"subject,omitempty"`
+	Before    State     `json:"before"`
+}
+
+// Target returns a readable name for the changed object
+func (e Entry) Target() string {
+	if e.Subject != "" {
+		return e.Subject
+	}
+	if e.ContactID != 0 {
+		return fmt.Sprintf("contact %d", e.ContactID)
+	}
+	return fmt.Sprintf("alias %d", e.AliasID)
+}
+
+// IsEmpty reports whether no field was recorded, leaving nothing to revert
+func (s State) IsEmpty() bool {
+	return s.Enabled == nil && s.Blocked == nil && s.Name == nil && s.Note == nil &&
+		s.Pinned == nil && s.MailboxIDs == nil
+}
+
+// String lists the recorded fields, e.g. `enabled, note "shop"`
+func (s State) String() string {
+	var fields []string
+
+	if s.Enabled != nil {
+		fields = append(fields, map[bool]string{true: "enabled", false: "disabled"}[*s.Enabled])
+	}
+	if s.Blocked != nil {
+		fields = append(fields, map[bool]string{true: "blocked", false: "unblocked"}[*s.Blocked])
+	}
+	if s.Name != nil {
+		fields = append(fields, fmt.Sprintf("name %q", *s.Name))
+	}
+	if s.Note != nil {
+		fields = append(fields, fmt.Sprintf("note %q", *s.Note))
+	}
+	if s.Pinned != nil {
+		fields = append(fields, map[bool]string{true: "pinned", false: "unpinned"}[*s.Pinned])
+	}
+	if s.MailboxIDs != nil {
+		fields = append(fields, fmt.Sprintf("mailboxes %v", s.MailboxIDs))
+	}
+
+	return strings.Join(fields, ", ")
+}
+
+// Journal is the list of recent changes of a profile, oldest first
+type Journal struct {
+	LastID  int     `json:"last_id"`
+	Entries []Entry `json:"entries"`
+
+	path string
+}
+
+// Path returns the default journal file of a profile
+func Path(profile string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "simplelogin-cli", "journal-"+profile+".json"), nil
+}
+
+// Load reads the journal file, an absent file holding no entries
+func Load(path string) (*Journal, error) {
+	j := &Journal{path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return j, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, j); err != nil {
+		return nil, fmt.Errorf("invalid journal %s: %w", path, err)
+	}
+
+	return j, nil
+}
+
+// Record appends an entry, numbering and dating it, and saves the journal
+func (j *Journal) Record(entry Entry) (Entry, error) {
+	// IDs are never reused, even after the latest entry was reverted
+	j.LastID++
+	entry.ID = j.LastID
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+
+	j.Entries = append(j.Entries, entry)
+	if len(j.Entries) > MaxEntries {
+		j.Entries = j.Entries[len(j.Entries)-MaxEntries:]
+	}
+
+	return entry, j.Save()
+}
+
+// appendMu serialises the journal updates of changes made concurrently
+var appendMu sync.Mutex
+
+// Append records an entry in the default journal of a profile
+func Append(profile string, entry Entry) error {
+	appendMu.Lock()
+	defer appendMu.Unlock()
+
+	path, err := Path(profile)
+	if err != nil {
+		return err
+	}
+
+	j, err := Load(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = j.Record(entry)
+	return err
+}
+
+// RecordChange records the state of an object before a change in the journal
+// of the active profile so that 'undo' can revert it. The change already
+// happened, so a failure is only described by the returned warning, empty on
+// success.
+func RecordChange(entry Entry) string {
+	if err := Append(config.ActiveProfile(), entry); err != nil {
+		return fmt.Sprintf("undo journal: %v", err)
+	}
+	return ""
+}
+
+// Warn records a change like RecordChange and prints the warning on stderr
+func Warn(entry Entry) {
+	if warning := RecordChange(entry); warning != "" {
+		fmt.Fprintf(os.Stderr, "⚠️  Warning: %s\n", warning)
+	}
+}
+
+// Find returns an entry by ID, or the latest one when id is 0
+func (j *Journal) Find(id int) (Entry, bool) {
+	for i := len(j.Entries) - 1; i >= 0; i-- {
+		if id == 0 || j.Entries[i].ID == id {
+			return j.Entries[i], true
+		}
+	}
+	return Entry{}, false
+}
+
+// Remove drops an entry once it was reverted and saves the journal
+func (j *Journal) Remove(id int) error {
+	for i, entry := range j.Entries {
+		if entry.ID == id {
+			j.Entries = append(j.Entries[:i], j.Entries[i+1:]...)
+			return j.Save()
+		}
+	}
+	return fmt.Errorf("journal entry %d not found", id)
+}
+
+// Save writes the journal, replacing the previous file atomically
+func (j *Journal) Save() error {
+	data, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0700); err != nil {
+		return err
+	}
+
+	return config.WriteFileAtomic(j.path, data)
+}
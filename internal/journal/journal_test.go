@@ -0,0 +1,100 @@
+package journal
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestJournal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "journal.json")
+
+	j, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := j.Find(0); ok {
+		t.Fatal("Find() on an empty journal returned an entry")
+	}
+
+	enabled := true
+	for i := range 3 {
+		entry, err := j.Record(Entry{Action: ActionAliasToggle, AliasID: i + 10, Before: State{Enabled: &enabled}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if entry.ID != i+1 || entry.Time.IsZero() {
+			t.Errorf("Record() = id %d, time %s, want id %d and a time", entry.ID, entry.Time, i+1)
+		}
+	}
+
+	if err := j.Remove(3); err != nil {
+		t.Fatal(err)
+	}
+
+	j, err = Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	latest, ok := j.Find(0)
+	if !ok || latest.ID != 2 || latest.AliasID != 11 || latest.Before.Enabled == nil || !*latest.Before.Enabled {
+		t.Errorf("Find(0) = %+v, %t, want entry 2 of alias 11", latest, ok)
+	}
+	if entry, ok := j.Find(1); !ok || entry.AliasID != 10 {
+		t.Errorf("Find(1) = %+v, %t, want alias 10", entry, ok)
+	}
+	if err := j.Remove(3); err == nil {
+		t.Error("Remove() of a removed entry succeeded")
+	}
+
+	// The ID of a reverted entry is not given to the next change
+	entry, err := j.Record(Entry{Action: ActionContactBlock, ContactID: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.ID != 4 {
+		t.Errorf("Record() after Remove() = id %d, want 4", entry.ID)
+	}
+}
+
+func TestJournal_MaxEntries(t *testing.T) {
+	j, err := Load(filepath.Join(t.TempDir(), "journal.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for range MaxEntries + 5 {
+		if _, err := j.Record(Entry{Action: ActionAliasUpdate}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(j.Entries) != MaxEntries {
+		t.Errorf("journal holds %d entries, want %d", len(j.Entries), MaxEntries)
+	}
+	if j.Entries[0].ID != 6 {
+		t.Errorf("oldest entry = %d, want 6", j.Entries[0].ID)
+	}
+}
+
+func TestState_String(t *testing.T) {
+	enabled, pinned := false, true
+	note := "shop\n#tags: a"
+
+	state := State{Enabled: &enabled, Note: &note, Pinned: &pinned, MailboxIDs: []int{1, 2}}
+	want := `disabled, note "shop\n#tags: a", pinned, mailboxes [1 2]`
+	if got := state.String(); got != want {
+		t.Errorf("String() = %s, want %s", got, want)
+	}
+}
+
+func TestState_IsEmpty(t *testing.T) {
+	if !(State{}).IsEmpty() {
+		t.Error("IsEmpty() = false for a zero State")
+	}
+
+	pinned := false
+	if (State{Pinned: &pinned}).IsEmpty() {
+		t.Error("IsEmpty() = true with a recorded field")
+	}
+}
@@ -0,0 +1,430 @@
+package mailwait
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/emersion/go-imap"
+	"github.com/emersion/go-imap/client"
+)
+
+// ErrTimeout is returned when no matching message arrived in time
+var ErrTimeout = errors.New("timed out waiting for mail")
+
+// LinkPattern extracts the first link of a message body
+var LinkPattern = regexp.MustCompile(`https?://[^\s"'<>()\[\]]+`)
+
+// otpPatterns are tried in order by ExtractOTP. A bare 4-8 digit number is
+// too loose on its own (years, order or street numbers), so digits next to a
+// word like "code" win, and only a lone 6 digit number is accepted otherwise.
+var otpPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(?:code|otp|passcode|pin|verification)\b\D{0,40}?\b(\d{4,8})\b`),
+	regexp.MustCompile(`(?i)\b(\d{4,8})\b[^\d\n]{0,30}?\b(?:code|otp|passcode|pin)\b`),
+	regexp.MustCompile(`\b\d{6}\b`),
+}
+
+// recipientHeaders are the headers checked for the alias address
+var recipientHeaders = []string{"To", "Cc", "Delivered-To", "X-Original-To", "X-SimpleLogin-Envelope-To"}
+
+// Config describes how to reach an IMAP mailbox
+type Config struct {
+	Address  string
+	Username string
+	Password string
+	TLS      bool
+	Folder   string
+}
+
+// Options configures what to wait for
+type Options struct {
+	Recipient    string
+	Since        time.Time
+	PollInterval time.Duration
+}
+
+// Message is a message delivered to the recipient
+type Message struct {
+	UID     uint32    `json:"uid"`
+	Date    time.Time `json:"date"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Subject string    `json:"subject"`
+	Body    string    `json:"body"`
+}
+
+// Wait polls the mailbox until a message for the recipient received after
+// opts.Since shows up, or ctx is done
+func Wait(ctx context.Context, cfg Config, opts Options) (*Message, error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+
+	c, err := connect(ctx, cfg)
+	if err != nil {
+		return nil, contextErr(ctx, err)
+	}
+	defer func() {
+		if ctx.Err() == nil {
+			c.Logout()
+		}
+	}()
+
+	// Closing the connection unblocks a command waiting on the server
+	stop := context.AfterFunc(ctx, func() { c.Terminate() })
+	defer stop()
+
+	var lastUID uint32
+	for {
+		setTimeout(ctx, c)
+
+		msg, err := poll(c, opts, &lastUID)
+		if err != nil {
+			return nil, contextErr(ctx, err)
+		}
+		if msg != nil {
+			return msg, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, contextErr(ctx, ctx.Err())
+		case <-time.After(opts.PollInterval):
+		}
+	}
+}
+
+// contextErr returns ErrTimeout or the cancellation error once ctx is done, err otherwise
+func contextErr(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return ErrTimeout
+	case ctx.Err() != nil:
+		return ctx.Err()
+	default:
+		return err
+	}
+}
+
+// Extract returns the first match of pattern in text, or its first capture group if it has one
+func Extract(text string, pattern *regexp.Regexp) (string, bool) {
+	match := pattern.FindStringSubmatch(text)
+	if match == nil {
+		return "", false
+	}
+	if len(match) > 1 {
+		return match[1], true
+	}
+	return match[0], true
+}
+
+// ExtractOTP returns the one-time code of a message body
+func ExtractOTP(text string) (string, bool) {
+	for _, pattern := range otpPatterns {
+		if value, ok := Extract(text, pattern); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// connect dials, logs in and selects the folder, within the deadline of ctx
+func connect(ctx context.Context, cfg Config) (*client.Client, error) {
+	dialer := contextDialer{ctx: ctx}
+
+	var c *client.Client
+	var err error
+	if cfg.TLS {
+		c, err = client.DialWithDialerTLS(dialer, cfg.Address, nil)
+	} else {
+		c, err = client.DialWithDialer(dialer, cfg.Address)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", cfg.Address, err)
+	}
+
+	setTimeout(ctx, c)
+
+	if err := c.Login(cfg.Username, cfg.Password); err != nil {
+		c.Terminate()
+		return nil, fmt.Errorf("IMAP login failed: %w", err)
+	}
+
+	folder := cfg.Folder
+	if folder == "" {
+		folder = "INBOX"
+	}
+	if _, err := c.Select(folder, true); err != nil {
+		c.Terminate()
+		return nil, fmt.Errorf("failed to select %s: %w", folder, err)
+	}
+
+	return c, nil
+}
+
+// contextDialer dials with the deadline of ctx, which also bounds the server greeting
+type contextDialer struct {
+	ctx context.Context
+}
+
+func (d contextDialer) Dial(network, address string) (net.Conn, error) {
+	conn, err := new(net.Dialer).DialContext(d.ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := d.ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+// setTimeout bounds the next IMAP commands by the time left before the deadline of ctx
+func setTimeout(ctx context.Context, c *client.Client) {
+	if deadline, ok := ctx.Deadline(); ok {
+		c.Timeout = max(time.Until(deadline), time.Millisecond)
+	}
+}
+
+// poll returns the most recent matching message, or nil when there is none yet.
+// Only the headers of messages newer than lastUID are fetched, and the body of
+// the matching one.
+func poll(c *client.Client, opts Options, lastUID *uint32) (*Message, error) {
+	criteria := imap.NewSearchCriteria()
+	if !opts.Since.IsZero() {
+		// SINCE has a one day granularity, the exact time is checked below
+		criteria.Since = opts.Since.AddDate(0, 0, -1)
+	}
+	if *lastUID > 0 {
+		criteria.Uid = new(imap.SeqSet)
+		criteria.Uid.AddRange(*lastUID+1, 0)
+	}
+
+	uids, err := c.UidSearch(criteria)
+	if err != nil {
+		return nil, fmt.Errorf("IMAP search failed: %w", err)
+	}
+
+	// "n:*" always matches the last message, even when it is older than n
+	var fresh []uint32
+	for _, uid := range uids {
+		if uid > *lastUID {
+			fresh = append(fresh, uid)
+		}
+	}
+	if len(fresh) == 0 {
+		return nil, nil
+	}
+
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(fresh...)
+
+	header := &imap.BodySectionName{BodyPartName: imap.BodyPartName{Specifier: imap.HeaderSpecifier}, Peek: true}
+	items := []imap.FetchItem{imap.FetchUid, imap.FetchInternalDate, header.FetchItem()}
+
+	messages := make(chan *imap.Message, len(fresh))
+	if err := c.UidFetch(seqset, items, messages); err != nil {
+		return nil, fmt.Errorf("IMAP fetch failed: %w", err)
+	}
+
+	var match *imap.Message
+	for m := range messages {
+		*lastUID = max(*lastUID, m.Uid)
+
+		if !opts.Since.IsZero() && m.InternalDate.Before(opts.Since) {
+			continue
+		}
+
+		literal := m.GetBody(header)
+		if literal == nil {
+			continue
+		}
+		h, err := textproto.NewReader(bufio.NewReader(literal)).ReadMIMEHeader()
+		if err != nil && len(h) == 0 {
+			continue
+		}
+		if !addressedTo(mail.Header(h), opts.Recipient) {
+			continue
+		}
+
+		if match == nil || m.Uid > match.Uid {
+			match = m
+		}
+	}
+
+	if match == nil {
+		return nil, nil
+	}
+
+	return fetchMessage(c, match, opts.Recipient)
+}
+
+// fetchMessage fetches and parses the full message m
+func fetchMessage(c *client.Client, m *imap.Message, recipient string) (*Message, error) {
+	seqset := new(imap.SeqSet)
+	seqset.AddNum(m.Uid)
+
+	section := &imap.BodySectionName{Peek: true}
+	messages := make(chan *imap.Message, 1)
+	if err := c.UidFetch(seqset, []imap.FetchItem{section.FetchItem()}, messages); err != nil {
+		return nil, fmt.Errorf("IMAP fetch failed: %w", err)
+	}
+
+	for full := range messages {
+		literal := full.GetBody(section)
+		if literal == nil {
+			continue
+		}
+
+		msg, err := parse(literal, recipient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse message %d: %w", m.Uid, err)
+		}
+		if msg != nil {
+			msg.UID = m.Uid
+			msg.Date = m.InternalDate
+			return msg, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// parse decodes a raw message, returning nil when it is not addressed to recipient
+func parse(r io.Reader, recipient string) (*Message, error) {
+	m, err := mail.ReadMessage(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if !addressedTo(m.Header, recipient) {
+		return nil, nil
+	}
+
+	decoder := new(mime.WordDecoder)
+	subject, err := decoder.DecodeHeader(m.Header.Get("Subject"))
+	if err != nil {
+		subject = m.Header.Get("Subject")
+	}
+
+	body, err := textBody(m.Header.Get("Content-Type"), m.Header.Get("Content-Transfer-Encoding"), m.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		From:    m.Header.Get("From"),
+		To:      m.Header.Get("To"),
+		Subject: subject,
+		Body:    body,
+	}, nil
+}
+
+func addressedTo(header mail.Header, recipient string) bool {
+	recipient = strings.ToLower(recipient)
+
+	for _, name := range recipientHeaders {
+		for _, value := range header[textproto.CanonicalMIMEHeaderKey(name)] {
+			if addresses, err := mail.ParseAddressList(value); err == nil {
+				for _, address := range addresses {
+					if strings.ToLower(address.Address) == recipient {
+						return true
+					}
+				}
+			} else if strings.Contains(strings.ToLower(value), recipient) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// textBody returns the text/plain part of a message, falling back to text/html
+func textBody(contentType, encoding string, body io.Reader) (string, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "text/plain"
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		data, err := io.ReadAll(decodeTransfer(encoding, body))
+		return string(data), err
+	}
+
+	var plain, html string
+	reader := multipart.NewReader(body, params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		partType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		switch {
+		case strings.HasPrefix(partType, "multipart/"):
+			text, err := textBody(part.Header.Get("Content-Type"), part.Header.Get("Content-Transfer-Encoding"), part)
+			if err != nil {
+				return "", err
+			}
+			if plain == "" {
+				plain = text
+			}
+		case partType == "text/plain" && plain == "":
+			// NextPart decodes quoted-printable parts and drops their
+			// Content-Transfer-Encoding, leaving base64 to decodeTransfer
+			data, err := io.ReadAll(decodeTransfer(part.Header.Get("Content-Transfer-Encoding"), part))
+			if err != nil {
+				return "", err
+			}
+			plain = string(data)
+		case partType == "text/html" && html == "":
+			data, err := io.ReadAll(decodeTransfer(part.Header.Get("Content-Transfer-Encoding"), part))
+			if err != nil {
+				return "", err
+			}
+			html = string(data)
+		}
+	}
+
+	if plain != "" {
+		return plain, nil
+	}
+	return html, nil
+}
+
+func decodeTransfer(encoding string, r io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "base64":
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return bytes.NewReader(nil)
+		}
+		data = bytes.Join(bytes.Fields(data), nil)
+		return base64.NewDecoder(base64.StdEncoding, bytes.NewReader(data))
+	case "quoted-printable":
+		return quotedprintable.NewReader(r)
+	default:
+		return r
+	}
+}
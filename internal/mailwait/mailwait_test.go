@@ -0,0 +1,175 @@
+package mailwait
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/emersion/go-imap/backend/memory"
+	"github.com/emersion/go-imap/server"
+)
+
+// startServer runs an in-memory IMAP server standing in for the real mailbox
+func startServer(t *testing.T) (*memory.Backend, string) {
+	t.Helper()
+
+	be := memory.New()
+	s := server.New(be)
+	s.AllowInsecureAuth = true
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go s.Serve(l)
+	t.Cleanup(func() { s.Close() })
+
+	return be, l.Addr().String()
+}
+
+func deliver(t *testing.T, be *memory.Backend, raw string) {
+	t.Helper()
+
+	user, err := be.Login(nil, "username", "password")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mbox, err := user.GetMailbox("INBOX")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := mbox.CreateMessage(nil, time.Now(), bytes.NewBufferString(raw)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWait(t *testing.T) {
+	be, addr := startServer(t)
+
+	cfg := Config{Address: addr, Username: "username", Password: "password"}
+	opts := Options{
+		Recipient:    "signup.xyz@aleeas.com",
+		Since:        time.Now().Add(-time.Minute),
+		PollInterval: 50 * time.Millisecond,
+	}
+
+	go func() {
+		time.Sleep(150 * time.Millisecond)
+		deliver(t, be, "From: other@example.org\r\n"+
+			"To: someone-else@aleeas.com\r\n"+
+			"Subject: Not for us\r\n"+
+			"\r\n"+
+			"Your code is 000000\r\n")
+		deliver(t, be, "From: noreply@shop.example.com\r\n"+
+			"To: Shop User <signup.xyz@aleeas.com>\r\n"+
+			"Subject: =?utf-8?q?V=C3=A9rify_your_account?=\r\n"+
+			"Content-Type: multipart/alternative; boundary=b1\r\n"+
+			"\r\n"+
+			"--b1\r\n"+
+			"Content-Type: text/plain; charset=utf-8\r\n"+
+			"Content-Transfer-Encoding: quoted-printable\r\n"+
+			"\r\n"+
+			"Your code is 482913.\r\n"+
+			"Or click https://shop.example.com/verify?t=3Dabc\r\n"+
+			"--b1\r\n"+
+			"Content-Type: text/html\r\n"+
+			"\r\n"+
+			"<p>html</p>\r\n"+
+			"--b1--\r\n")
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	msg, err := Wait(ctx, cfg, opts)
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+
+	if msg.Subject != "Vérify your account" {
+		t.Errorf("Subject = %q", msg.Subject)
+	}
+	if otp, _ := ExtractOTP(msg.Body); otp != "482913" {
+		t.Errorf("OTP = %q, body %q", otp, msg.Body)
+	}
+	if link, _ := Extract(msg.Body, LinkPattern); link != "https://shop.example.com/verify?t=abc" {
+		t.Errorf("link = %q", link)
+	}
+	if strings.Contains(msg.Body, "<p>") {
+		t.Errorf("expected the text/plain part, got %q", msg.Body)
+	}
+}
+
+func TestWait_Timeout(t *testing.T) {
+	_, addr := startServer(t)
+
+	cfg := Config{Address: addr, Username: "username", Password: "password"}
+	opts := Options{
+		Recipient:    "nobody@aleeas.com",
+		Since:        time.Now(),
+		PollInterval: 50 * time.Millisecond,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if _, err := Wait(ctx, cfg, opts); err != ErrTimeout {
+		t.Errorf("Wait() error = %v, want %v", err, ErrTimeout)
+	}
+}
+
+func TestWait_UnresponsiveServer(t *testing.T) {
+	// The server accepts connections but never sends its greeting
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	cfg := Config{Address: l.Addr().String(), Username: "username", Password: "password"}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err := Wait(ctx, cfg, Options{Recipient: "nobody@aleeas.com"}); err != ErrTimeout {
+		t.Errorf("Wait() error = %v, want %v", err, ErrTimeout)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Wait() returned after %s, want the 200ms timeout honoured", elapsed)
+	}
+}
+
+func TestExtractOTP(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"date line before the code", "Date: 19 October 2026\nOrder 10452\n\nYour verification code is 482913.", "482913"},
+		{"code on the next line", "Sent 2026-10-19\nYour one-time code:\n\n  7731\n", "7731"},
+		{"code before the keyword", "Hi,\n48291 is your login code.\n", "48291"},
+		{"lone six digits", "Year 2026, use 902114 to continue", "902114"},
+		{"no code", "Welcome! Your account was created in 2026.", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ExtractOTP(tt.body)
+			if got != tt.want || ok != (tt.want != "") {
+				t.Errorf("ExtractOTP() = %q, %v, want %q", got, ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// ProtocolVersion is the latest MCP revision implemented
+const ProtocolVersion = "2025-06-18"
+
+var supportedVersions = []string{ProtocolVersion, "2025-03-26", "2024-11-05"}
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// Tool is an operation exposed to MCP clients
+type Tool struct {
+	Name        string
+	Description string
+	// Input is a zero value of the argument struct, used for the schema and decoding
+	Input any
+	// Destructive tools change or remove data and require "confirm": true
+	Destructive bool
+	ReadOnly    bool
+	Handler     func(ctx context.Context, args json.RawMessage) (any, error)
+}
+
+// Server serves tools over the MCP stdio transport: newline delimited
+// JSON-RPC 2.0 messages
+type Server struct {
+	name    string
+	version string
+	tools   []Tool
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+// NewServer returns a server exposing tools
+func NewServer(name, version string, tools []Tool) *Server {
+	return &Server{name: name, version: version, tools: tools}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Serve reads requests from r and writes responses to w until r is closed
+// or ctx is cancelled
+func (s *Server) Serve(ctx context.Context, r io.Reader, w io.Writer) error {
+	s.out = w
+	reader := bufio.NewReader(r)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		line, err := reader.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			s.handle(ctx, line)
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func (s *Server) handle(ctx context.Context, line []byte) {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		s.write(response{ID: json.RawMessage("null"), Error: &rpcError{Code: codeParseError, Message: err.Error()}})
+		return
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		s.write(response{ID: idOrNull(req.ID), Error: &rpcError{Code: codeInvalidRequest, Message: "invalid JSON-RPC 2.0 request"}})
+		return
+	}
+
+	result, rpcErr := s.dispatch(ctx, req)
+
+	// Notifications get no response
+	if req.ID == nil {
+		return
+	}
+
+	s.write(response{ID: req.ID, Result: result, Error: rpcErr})
+}
+
+func (s *Server) dispatch(ctx context.Context, req request) (any, *rpcError) {
+	switch req.Method {
+	case "initialize":
+		var params struct {
+			ProtocolVersion string `json:"protocolVersion"`
+		}
+		json.Unmarshal(req.Params, &params)
+
+		version := ProtocolVersion
+		if slices.Contains(supportedVersions, params.ProtocolVersion) {
+			version = params.ProtocolVersion
+		}
+
+		return map[string]any{
+			"protocolVersion": version,
+			"capabilities":    map[string]any{"tools": map[string]any{}},
+			"serverInfo":      map[string]string{"name": s.name, "version": s.version},
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		return map[string]any{"tools": s.listTools()}, nil
+	case "tools/call":
+		return s.callTool(ctx, req.Params)
+	default:
+		if strings.HasPrefix(req.Method, "notifications/") {
+			return nil, nil
+		}
+		return nil, &rpcError{Code: codeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+}
+
+func (s *Server) listTools() []map[string]any {
+	tools := make([]map[string]any, 0, len(s.tools))
+
+	for _, tool := range s.tools {
+		schema := Schema(tool.Input)
+		if tool.Destructive {
+			properties := schema["properties"].(map[string]any)
+			properties["confirm"] = map[string]any{
+				"type":        "boolean",
+				"description": "Must be true, after the user confirmed this change",
+			}
+			required, _ := schema["required"].([]string)
+			schema["required"] = append(required, "confirm")
+		}
+
+		tools = append(tools, map[string]any{
+			"name":        tool.Name,
+			"description": tool.Description,
+			"inputSchema": schema,
+			"annotations": map[string]bool{
+				"destructiveHint": tool.Destructive,
+				"readOnlyHint":    tool.ReadOnly,
+			},
+		})
+	}
+
+	return tools
+}
+
+func (s *Server) callTool(ctx context.Context, raw json.RawMessage) (any, *rpcError) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, &rpcError{Code: codeInvalidParams, Message: err.Error()}
+	}
+
+	idx := slices.IndexFunc(s.tools, func(tool Tool) bool { return tool.Name == params.Name })
+	if idx < 0 {
+		return nil, &rpcError{Code: codeInvalidParams, Message: "unknown tool: " + params.Name}
+	}
+	tool := s.tools[idx]
+
+	if len(params.Arguments) == 0 {
+		params.Arguments = json.RawMessage("{}")
+	}
+
+	if tool.Destructive {
+		var confirmation struct {
+			Confirm bool `json:"confirm"`
+		}
+		json.Unmarshal(params.Arguments, &confirmation)
+		if !confirmation.Confirm {
+			return toolError(fmt.Errorf("%s changes the account: ask the user, then call it again with \"confirm\": true", tool.Name)), nil
+		}
+	}
+
+	result, err := tool.Handler(ctx, params.Arguments)
+	if err != nil {
+		return toolError(err), nil
+	}
+
+	text, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return toolError(err), nil
+	}
+
+	return map[string]any{
+		"content": []map[string]string{{"type": "text", "text": string(text)}},
+		"isError": false,
+	}, nil
+}
+
+func toolError(err error) map[string]any {
+	return map[string]any{
+		"content": []map[string]string{{"type": "text", "text": err.Error()}},
+		"isError": true,
+	}
+}
+
+func (s *Server) write(resp response) {
+	resp.JSONRPC = "2.0"
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.out.Write(append(data, '\n'))
+}
+
+func idOrNull(id json.RawMessage) json.RawMessage {
+	if id == nil {
+		return json.RawMessage("null")
+	}
+	return id
+}
+
+// Decode unmarshals tool arguments into T
+func Decode[T any](args json.RawMessage) (T, error) {
+	var v T
+	if err := json.Unmarshal(args, &v); err != nil {
+		return v, fmt.Errorf("invalid arguments: %w", err)
+	}
+	return v, nil
+}
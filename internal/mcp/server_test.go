@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testInput struct {
+	Name  string   `json:"name" required:"true" description:"A name"`
+	Count *int     `json:"count,omitempty"`
+	Tags  []string `json:"tags"`
+}
+
+func TestSchema(t *testing.T) {
+	got := Schema(testInput{})
+	want := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name":  map[string]any{"type": "string", "description": "A name"},
+			"count": map[string]any{"type": "integer"},
+			"tags":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+		},
+		"required": []string{"name"},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Schema() = %#v, want %#v", got, want)
+	}
+}
+
+func TestServer(t *testing.T) {
+	var called bool
+	server := NewServer("test", "dev", []Tool{{
+		Name:        "delete_thing",
+		Input:       testInput{},
+		Destructive: true,
+		Handler: func(ctx context.Context, args json.RawMessage) (any, error) {
+			called = true
+			input, err := Decode[testInput](args)
+			return map[string]string{"deleted": input.Name}, err
+		},
+	}})
+
+	in := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-03-26"}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		`{"jsonrpc":"2.0","id":2,"method":"tools/list"}`,
+		`{"jsonrpc":"2.0","id":3,"method":"tools/call","params":{"name":"delete_thing","arguments":{"name":"x"}}}`,
+		`{"jsonrpc":"2.0","id":4,"method":"tools/call","params":{"name":"delete_thing","arguments":{"name":"x","confirm":true}}}`,
+		`{"jsonrpc":"2.0","id":5,"method":"unknown"}`,
+	}, "\n")
+
+	var out bytes.Buffer
+	if err := server.Serve(context.Background(), strings.NewReader(in), &out); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d responses, want 5:\n%s", len(lines), out.String())
+	}
+
+	var responses []map[string]any
+	for _, line := range lines {
+		var resp map[string]any
+		if err := json.Unmarshal([]byte(line), &resp); err != nil {
+			t.Fatal(err)
+		}
+		responses = append(responses, resp)
+	}
+
+	if v := responses[0]["result"].(map[string]any)["protocolVersion"]; v != "2025-03-26" {
+		t.Errorf("negotiated version = %v", v)
+	}
+
+	tool := responses[1]["result"].(map[string]any)["tools"].([]any)[0].(map[string]any)
+	required := tool["inputSchema"].(map[string]any)["required"].([]any)
+	if len(required) != 2 || required[1] != "confirm" {
+		t.Errorf("destructive tool required = %v, want confirm", required)
+	}
+
+	if isError := responses[2]["result"].(map[string]any)["isError"]; isError != true {
+		t.Error("destructive tool ran without confirmation")
+	}
+	if isError := responses[3]["result"].(map[string]any)["isError"]; isError != false || !called {
+		t.Errorf("confirmed call failed: %v", responses[3])
+	}
+
+	if code := responses[4]["error"].(map[string]any)["code"]; code != float64(codeMethodNotFound) {
+		t.Errorf("unknown method error code = %v", code)
+	}
+}
@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+type listAliasesInput struct {
+	simplelogin.AliasListOptions
+	PageID *int `json:"page_id,omitempty" description:"Page to return, 20 aliases per page; all aliases when omitted"`
+}
+
+type aliasInput struct {
+	AliasID int `json:"alias_id" required:"true" description:"Alias ID"`
+}
+
+type aliasPageInput struct {
+	AliasID int  `json:"alias_id" required:"true" description:"Alias ID"`
+	PageID  *int `json:"page_id,omitempty" description:"Page to return; all pages when omitted"`
+}
+
+type contactInput struct {
+	ContactID int `json:"contact_id" required:"true" description:"Contact ID, from list_alias_contacts"`
+}
+
+// AliasTools returns the alias tools, backed by client
+func AliasTools(client *simplelogin.Client) []Tool {
+	return []Tool{
+		{
+			Name:        "list_aliases",
+			Description: "List or search the aliases of the account",
+			Input:       listAliasesInput{},
+			ReadOnly:    true,
+			Handler: func(ctx context.Context, args json.RawMessage) (any, error) {
+				input, err := Decode[listAliasesInput](args)
+				if err != nil {
+					return nil, err
+				}
+				if input.PageID != nil {
+					return client.GetAliases(input.AliasListOptions, *input.PageID)
+				}
+				return client.GetAllAliases(input.AliasListOptions)
+			},
+		},
+		{
+			Name:        "get_alias",
+			Description: "Get an alias by ID",
+			Input:       aliasInput{},
+			ReadOnly:    true,
+			Handler: func(ctx context.Context, args json.RawMessage) (any, error) {
+				input, err := Decode[aliasInput](args)
+				if err != nil {
+					return nil, err
+				}
+				return client.GetAlias(input.AliasID)
+			},
+		},
+		{
+			Name:        "create_random_alias",
+			Description: "Create a random alias, optionally for a website hostname",
+			Input:       simplelogin.AliasCreateRandomOptions{},
+			Handler: func(ctx context.Context, args json.RawMessage) (any, error) {
+				input, err := Decode[simplelogin.AliasCreateRandomOptions](args)
+				if err != nil {
+					return nil, err
+				}
+				return client.CreateRandomAlias(input.Hostname, input.Mode, input.Note)
+			},
+		},
+		{
+			Name:        "toggle_alias",
+			Description: "Enable a disabled alias or disable an enabled one; a disabled alias stops forwarding mail",
+			Input:       aliasInput{},
+			Destructive: true,
+			Handler: func(ctx context.Context, args json.RawMessage) (any, error) {
+				input, err := Decode[aliasInput](args)
+				if err != nil {
+					return nil, err
+				}
+				return client.ToggleAlias(input.AliasID)
+			},
+		},
+		{
+			Name:        "get_alias_activities",
+			Description: "List the forwarded, replied and blocked emails of an alias",
+			Input:       aliasPageInput{},
+			ReadOnly:    true,
+			Handler: func(ctx context.Context, args json.RawMessage) (any, error) {
+				input, err := Decode[aliasPageInput](args)
+				if err != nil {
+					return nil, err
+				}
+				if input.PageID != nil {
+					return client.GetAliasActivities(input.AliasID, *input.PageID)
+				}
+				return client.GetAllAliasActivities(input.AliasID)
+			},
+		},
+		{
+			Name:        "list_alias_contacts",
+			Description: "List the contacts of an alias, with their IDs and block status",
+			Input:       aliasPageInput{},
+			ReadOnly:    true,
+			Handler: func(ctx context.Context, args json.RawMessage) (any, error) {
+				input, err := Decode[aliasPageInput](args)
+				if err != nil {
+					return nil, err
+				}
+				if input.PageID != nil {
+					return client.GetAliasContacts(input.AliasID, *input.PageID)
+				}
+				return client.GetAllAliasContacts(input.AliasID)
+			},
+		},
+		{
+			Name:        "block_contact",
+			Description: "Block a contact so that its emails are no longer forwarded; blocking an already blocked contact keeps it blocked",
+			Input:       contactInput{},
+			Destructive: true,
+			Handler: func(ctx context.Context, args json.RawMessage) (any, error) {
+				input, err := Decode[contactInput](args)
+				if err != nil {
+					return nil, err
+				}
+
+				// The API only toggles: toggle back when the contact was already blocked
+				result, err := client.ToggleContact(input.ContactID)
+				if err == nil && !result.BlockForward {
+					result, err = client.ToggleContact(input.ContactID)
+				}
+				if err != nil {
+					return nil, err
+				}
+				if !result.BlockForward {
+					return nil, fmt.Errorf("contact %d could not be blocked", input.ContactID)
+				}
+
+				return result, nil
+			},
+		},
+	}
+}
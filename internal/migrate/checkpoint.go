@@ -0,0 +1,90 @@
+package migrate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+)
+
+// Alias statuses recorded in the checkpoint
+const (
+	StatusMigrated     = "migrated"
+	StatusExists       = "exists"
+	StatusUnmigratable = "unmigratable"
+	StatusFailed       = "failed"
+	StatusPlanned      = "planned"
+)
+
+// Result records what happened to a source alias
+type Result struct {
+	Source      string `json:"source"`
+	Destination string `json:"destination,omitempty"`
+	ID          int    `json:"id,omitempty"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// Checkpoint records the progress of a migration so that it can resume
+// where it stopped. Failed aliases are retried on the next run.
+type Checkpoint struct {
+	From     string            `json:"from"`
+	To       string            `json:"to"`
+	Settings bool              `json:"settings"`
+	Aliases  map[string]Result `json:"aliases"`
+
+	path string
+}
+
+// Load reads the checkpoint at path, or starts a new one when it does not exist
+func Load(path, from, to string) (*Checkpoint, error) {
+	cp := &Checkpoint{From: from, To: to, Aliases: map[string]Result{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cp, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, cp); err != nil {
+		return nil, fmt.Errorf("invalid checkpoint %s: %w", path, err)
+	}
+	if cp.From != from || cp.To != to {
+		return nil, fmt.Errorf("checkpoint %s is for %s -> %s, not %s -> %s", path, cp.From, cp.To, from, to)
+	}
+	if cp.Aliases == nil {
+		cp.Aliases = map[string]Result{}
+	}
+
+	return cp, nil
+}
+
+// Done reports whether a source alias needs no further work
+func (cp *Checkpoint) Done(source string) (Result, bool) {
+	result, ok := cp.Aliases[source]
+	return result, ok && (result.Status == StatusMigrated || result.Status == StatusExists)
+}
+
+// Record stores the result of a source alias and saves the checkpoint
+func (cp *Checkpoint) Record(result Result) error {
+	cp.Aliases[result.Source] = result
+	return cp.Save()
+}
+
+// Save writes the checkpoint, replacing the previous file atomically
+func (cp *Checkpoint) Save() error {
+	data, err := json.MarshalIndent(cp, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cp.path), 0700); err != nil {
+		return err
+	}
+
+	return config.WriteFileAtomic(cp.path, data)
+}
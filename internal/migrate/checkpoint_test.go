@@ -0,0 +1,38 @@
+package migrate
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckpoint_Resume(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "migrate.json")
+
+	cp, err := Load(path, "hosted", "selfhosted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := cp.Record(Result{Source: "a@example.com", Destination: "a@example.com", ID: 1, Status: StatusMigrated}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.Record(Result{Source: "b@example.com", Status: StatusFailed, Reason: "timeout"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cp, err = Load(path, "hosted", "selfhosted")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result, done := cp.Done("a@example.com"); !done || result.ID != 1 {
+		t.Errorf("Done(a) = %+v, %t", result, done)
+	}
+	if _, done := cp.Done("b@example.com"); done {
+		t.Error("Done(b) = true, failed aliases must be retried")
+	}
+
+	if _, err := Load(path, "hosted", "other"); err == nil {
+		t.Error("Load() accepted a checkpoint of another migration")
+	}
+}
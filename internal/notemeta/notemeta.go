@@ -0,0 +1,75 @@
+package notemeta
+
+import (
+	"strings"
+)
+
+// Metadata is stored in alias notes as "#key: value" lines, leaving the rest
+// of the note untouched. It is the only free metadata slot SimpleLogin offers.
+
+const prefix = "#"
+
+// Get returns the value of a metadata key
+func Get(note, key string) (string, bool) {
+	for _, line := range strings.Split(note, "\n") {
+		if k, v, ok := parseLine(line); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
+
+// Set adds or replaces a metadata key and returns the new note
+func Set(note, key, value string) string {
+	entry := prefix + key + ": " + value
+
+	lines := splitLines(note)
+	for i, line := range lines {
+		if k, _, ok := parseLine(line); ok && k == key {
+			lines[i] = entry
+			return strings.Join(lines, "\n")
+		}
+	}
+
+	return strings.Join(append(lines, entry), "\n")
+}
+
+// Delete removes a metadata key and returns the new note
+func Delete(note, key string) string {
+	var kept []string
+	for _, line := range splitLines(note) {
+		if k, _, ok := parseLine(line); ok && k == key {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+func parseLine(line string) (string, string, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, prefix) {
+		return "", "", false
+	}
+
+	key, value, ok := strings.Cut(strings.TrimPrefix(line, prefix), ":")
+	if !ok {
+		return "", "", false
+	}
+
+	key = strings.TrimSpace(key)
+	if key == "" || strings.ContainsAny(key, " \t") {
+		return "", "", false
+	}
+
+	return strings.ToLower(key), strings.TrimSpace(value), true
+}
+
+// splitLines splits a note into lines, dropping trailing blank lines
+func splitLines(note string) []string {
+	note = strings.TrimRight(note, "\n\r\t ")
+	if note == "" {
+		return nil
+	}
+	return strings.Split(note, "\n")
+}
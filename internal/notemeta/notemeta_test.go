@@ -0,0 +1,39 @@
+package notemeta
+
+import (
+	"testing"
+)
+
+func TestSetGetDelete(t *testing.T) {
+	note := "Used for the newsletter\n"
+
+	note = Set(note, "site", "example.com")
+	if note != "Used for the newsletter\n#site: example.com" {
+		t.Errorf("Set() = %q", note)
+	}
+
+	note = Set(note, "site", "example.org")
+	if v, ok := Get(note, "site"); !ok || v != "example.org" {
+		t.Errorf("Get() = %q, %t", v, ok)
+	}
+
+	note = Delete(note, "site")
+	if note != "Used for the newsletter" {
+		t.Errorf("Delete() = %q", note)
+	}
+
+	if _, ok := Get(note, "site"); ok {
+		t.Error("Get() found a deleted key")
+	}
+}
+
+func TestGet_IgnoresPlainHashes(t *testing.T) {
+	note := "# not metadata\n#hashtag\n#tags: a,b"
+
+	if _, ok := Get(note, "not"); ok {
+		t.Error("Get() parsed a comment as metadata")
+	}
+	if v, _ := Get(note, "tags"); v != "a,b" {
+		t.Errorf("Get() = %q, want %q", v, "a,b")
+	}
+}
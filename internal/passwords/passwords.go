@@ -0,0 +1,169 @@
+package passwords
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// Supported export formats
+const (
+	FormatBitwarden = "bitwarden"
+	FormatKeePass   = "keepass"
+	Format1Password = "1password"
+)
+
+// Formats lists the supported export formats
+var Formats = []string{FormatBitwarden, FormatKeePass, Format1Password}
+
+// Login is a login read from a password manager export. Passwords are never kept.
+type Login struct {
+	Name     string `json:"name"`
+	URL      string `json:"url,omitempty"`
+	Username string `json:"username"`
+}
+
+// DetectFormat guesses the export format from the file name and content
+func DetectFormat(path string, data []byte) (string, error) {
+	if strings.EqualFold(filepath.Ext(path), ".json") || bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return FormatBitwarden, nil
+	}
+
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	header = bytes.ToLower(header)
+
+	switch {
+	case bytes.Contains(header, []byte("login_username")):
+		return FormatBitwarden, nil
+	case bytes.Contains(header, []byte("group")):
+		return FormatKeePass, nil
+	case bytes.Contains(header, []byte("title")):
+		return Format1Password, nil
+	}
+
+	return "", fmt.Errorf("cannot detect the format of %s, expected one of %s", path, strings.Join(Formats, ", "))
+}
+
+// Parse reads the logins of an export; Bitwarden exports may be JSON or CSV
+func Parse(format string, data []byte) ([]Login, error) {
+	switch format {
+	case FormatBitwarden:
+		if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+			return parseBitwardenJSON(data)
+		}
+		return parseCSV(bytes.NewReader(data))
+	case FormatKeePass, Format1Password:
+		return parseCSV(bytes.NewReader(data))
+	default:
+		return nil, fmt.Errorf("unsupported format %q, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// bitwardenExport is the unencrypted JSON export of Bitwarden
+type bitwardenExport struct {
+	Encrypted bool `json:"encrypted"`
+	Items     []struct {
+		Type  int    `json:"type"`
+		Name  string `json:"name"`
+		Login *struct {
+			Username string `json:"username"`
+			URIs     []struct {
+				URI string `json:"uri"`
+			} `json:"uris"`
+		} `json:"login"`
+	} `json:"items"`
+}
+
+func parseBitwardenJSON(data []byte) ([]Login, error) {
+	var export bitwardenExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, fmt.Errorf("invalid Bitwarden export: %w", err)
+	}
+	if export.Encrypted {
+		return nil, fmt.Errorf("encrypted Bitwarden exports are not supported, export as unencrypted JSON")
+	}
+
+	var logins []Login
+	for _, item := range export.Items {
+		if item.Login == nil || item.Login.Username == "" {
+			continue
+		}
+
+		login := Login{Name: item.Name, Username: strings.TrimSpace(item.Login.Username)}
+		if len(item.Login.URIs) > 0 {
+			login.URL = item.Login.URIs[0].URI
+		}
+
+		logins = append(logins, login)
+	}
+
+	return logins, nil
+}
+
+// Column names used by the CSV exports of Bitwarden, KeePass(XC) and 1Password
+var (
+	nameColumns     = []string{"title", "name"}
+	urlColumns      = []string{"url", "login_uri", "website", "urls"}
+	usernameColumns = []string{"username", "login_username", "user name", "login"}
+)
+
+func parseCSV(r io.Reader) ([]Login, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int, len(records[0]))
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	usernameIndex := findColumn(columns, usernameColumns)
+	if usernameIndex < 0 {
+		return nil, fmt.Errorf("missing username column in CSV header")
+	}
+	nameIndex := findColumn(columns, nameColumns)
+	urlIndex := findColumn(columns, urlColumns)
+
+	var logins []Login
+	for _, record := range records[1:] {
+		login := Login{
+			Name:     field(record, nameIndex),
+			URL:      field(record, urlIndex),
+			Username: field(record, usernameIndex),
+		}
+		if login.Username == "" {
+			continue
+		}
+
+		logins = append(logins, login)
+	}
+
+	return logins, nil
+}
+
+func findColumn(columns map[string]int, names []string) int {
+	for _, name := range names {
+		if i, ok := columns[name]; ok {
+			return i
+		}
+	}
+	return -1
+}
+
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
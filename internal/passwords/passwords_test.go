@@ -0,0 +1,65 @@
+package passwords
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		input string
+		want  []Login
+	}{
+		{
+			name: "bitwarden json",
+			path: "bitwarden.json",
+			input: `{"encrypted":false,"items":[
+				{"type":1,"name":"Shop","login":{"username":"shop@example.com","password":"x","uris":[{"uri":"https://shop.example.net/login"}]}},
+				{"type":2,"name":"Secure note"}]}`,
+			want: []Login{{Name: "Shop", URL: "https://shop.example.net/login", Username: "shop@example.com"}},
+		},
+		{
+			name:  "bitwarden csv",
+			path:  "bitwarden.csv",
+			input: "folder,favorite,type,name,notes,fields,reprompt,login_uri,login_username,login_password,login_totp\n,,login,Forum,,,0,https://forum.example.org,forum@example.com,x,\n",
+			want:  []Login{{Name: "Forum", URL: "https://forum.example.org", Username: "forum@example.com"}},
+		},
+		{
+			name:  "keepass",
+			path:  "keepass.csv",
+			input: "\"Group\",\"Title\",\"Username\",\"Password\",\"URL\",\"Notes\"\n\"Root\",\"Bank\",\"me@example.org\",\"x\",\"https://bank.example\",\"\"\n\"Root\",\"Wifi\",\"\",\"x\",\"\",\"\"\n",
+			want:  []Login{{Name: "Bank", URL: "https://bank.example", Username: "me@example.org"}},
+		},
+		{
+			name:  "1password",
+			path:  "1password.csv",
+			input: "Title,Url,Username,Password,OTPAuth,Favorite,Archived,Tags,Notes\nNews,https://news.example,news@example.com,x,,false,false,,\n",
+			want:  []Login{{Name: "News", URL: "https://news.example", Username: "news@example.com"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := DetectFormat(tt.path, []byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Parse(format, []byte(tt.input))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Encrypted(t *testing.T) {
+	if _, err := Parse(FormatBitwarden, []byte(`{"encrypted":true,"items":[]}`)); err == nil {
+		t.Error("Parse() accepted an encrypted export")
+	}
+}
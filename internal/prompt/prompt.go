@@ -0,0 +1,207 @@
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// ErrNotTerminal is returned when an interactive prompt is requested without a terminal
+var ErrNotTerminal = errors.New("stdin is not a terminal")
+
+var stdin = bufio.NewReader(os.Stdin)
+
+// IsTerminal reports whether stdin is attached to a terminal
+func IsTerminal() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// Line prompts for a single line of input
+func Line(label string) (string, error) {
+	fmt.Fprint(os.Stderr, label)
+
+	line, err := stdin.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
+
+// Password prompts for a secret without echoing it. Only the line ending is
+// removed: spaces are part of the secret.
+func Password(label string) (string, error) {
+	if !IsTerminal() {
+		return "", ErrNotTerminal
+	}
+
+	fmt.Fprint(os.Stderr, label)
+	data, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// NewPassword prompts for a secret twice and checks both entries match
+func NewPassword(label string) (string, error) {
+	password, err := Password(label)
+	if err != nil {
+		return "", err
+	}
+
+	confirm, err := Password("Confirm " + strings.ToLower(label[:1]) + label[1:])
+	if err != nil {
+		return "", err
+	}
+
+	if password != confirm {
+		return "", errors.New("entries do not match")
+	}
+
+	return password, nil
+}
+
+// LineDefault prompts for a single line of input, returning def when the answer is empty
+func LineDefault(label, def string) (string, error) {
+	if def != "" {
+		label = fmt.Sprintf("%s [%s]: ", label, def)
+	} else {
+		label = label + ": "
+	}
+
+	answer, err := Line(label)
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return def, nil
+	}
+
+	return answer, nil
+}
+
+// Confirm asks a yes/no question
+func Confirm(label string, def bool) (bool, error) {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+
+	answer, err := Line(fmt.Sprintf("%s [%s]: ", label, choices))
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(answer) {
+	case "":
+		return def, nil
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("invalid answer %q", answer)
+	}
+}
+
+// Select displays a numbered list and returns the index of the chosen option
+func Select(label string, options []string, def int) (int, error) {
+	if len(options) == 0 {
+		return -1, errors.New("nothing to select")
+	}
+
+	fmt.Fprintln(os.Stderr, label)
+	for i, option := range options {
+		fmt.Fprintf(os.Stderr, "  %2d) %s\n", i+1, option)
+	}
+
+	answer, err := Line(fmt.Sprintf("Choice [%d]: ", def+1))
+	if err != nil {
+		return -1, err
+	}
+	if answer == "" {
+		return def, nil
+	}
+
+	return parseChoice(answer, len(options))
+}
+
+// MultiSelect displays a numbered list and returns the indexes of the chosen
+// options, entered as a comma separated list
+func MultiSelect(label string, options []string, defs []int) ([]int, error) {
+	if len(options) == 0 {
+		return nil, errors.New("nothing to select")
+	}
+
+	fmt.Fprintln(os.Stderr, label)
+	for i, option := range options {
+		fmt.Fprintf(os.Stderr, "  %2d) %s\n", i+1, option)
+	}
+
+	defLabels := make([]string, len(defs))
+	for i, def := range defs {
+		defLabels[i] = strconv.Itoa(def + 1)
+	}
+
+	answer, err := Line(fmt.Sprintf("Choices, comma separated [%s]: ", strings.Join(defLabels, ",")))
+	if err != nil {
+		return nil, err
+	}
+	if answer == "" {
+		return defs, nil
+	}
+
+	var choices []int
+	for _, item := range strings.Split(answer, ",") {
+		choice, err := parseChoice(strings.TrimSpace(item), len(options))
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(choices, choice) {
+			choices = append(choices, choice)
+		}
+	}
+
+	return choices, nil
+}
+
+func parseChoice(answer string, count int) (int, error) {
+	choice, err := strconv.Atoi(answer)
+	if err != nil || choice < 1 || choice > count {
+		return -1, fmt.Errorf("invalid choice %q", answer)
+	}
+	return choice - 1, nil
+}
+
+// ErrNotConfirmed is returned when a destructive action is declined or cannot be confirmed
+var ErrNotConfirmed = errors.New("not confirmed")
+
+// ConfirmAction asks before a destructive action unless yes is set. Without
+// a terminal it refuses, so that scripts have to pass --yes explicitly.
+func ConfirmAction(label string, yes bool) error {
+	if yes {
+		return nil
+	}
+	if !IsTerminal() {
+		return fmt.Errorf("%w: stdin is not a terminal, use --yes", ErrNotConfirmed)
+	}
+
+	ok, err := Confirm(label, false)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotConfirmed
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package prompt
+
+import (
+	"bufio"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMultiSelect(t *testing.T) {
+	tests := []struct {
+		answer  string
+		want    []int
+		wantErr bool
+	}{
+		{answer: "", want: []int{0}},
+		{answer: "2,3", want: []int{1, 2}},
+		{answer: "1, 1,3,1", want: []int{0, 2}},
+		{answer: "4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.answer, func(t *testing.T) {
+			stdin = bufio.NewReader(strings.NewReader(tt.answer + "\n"))
+
+			got, err := MultiSelect("Pick:", []string{"a", "b", "c"}, []int{0})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MultiSelect() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !slices.Equal(got, tt.want) {
+				t.Errorf("MultiSelect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
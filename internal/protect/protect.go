@@ -0,0 +1,58 @@
+package protect
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+// ErrProtected is returned when deleting an alias matched by a protection rule
+var ErrProtected = errors.New("alias is protected")
+
+// Rules are the compiled protection rules of the configuration
+type Rules struct {
+	pinned   bool
+	patterns []pattern
+}
+
+type pattern struct {
+	source string
+	re     *regexp.Regexp
+}
+
+// New compiles the protection rules; patterns match the alias email, ignoring case
+func New(cfg *config.ProtectConfig) (*Rules, error) {
+	rules := &Rules{}
+	if cfg == nil {
+		return rules, nil
+	}
+
+	rules.pinned = cfg.Pinned
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid protect pattern %q: %w", p, err)
+		}
+		rules.patterns = append(rules.patterns, pattern{source: p, re: re})
+	}
+
+	return rules, nil
+}
+
+// Check returns an error wrapping ErrProtected when the alias must not be deleted
+func (r *Rules) Check(alias simplelogin.Alias) error {
+	if r.pinned && alias.Pinned {
+		return fmt.Errorf("%w: %s is pinned", ErrProtected, alias.Email)
+	}
+
+	for _, p := range r.patterns {
+		if p.re.MatchString(alias.Email) {
+			return fmt.Errorf("%w: %s matches %q", ErrProtected, alias.Email, p.source)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package protect
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+func TestRules_Check(t *testing.T) {
+	rules, err := New(&config.ProtectConfig{
+		Pinned:   true,
+		Patterns: []string{`^billing@`, `@bank\.example\.com$`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		alias     simplelogin.Alias
+		protected bool
+	}{
+		{name: "pinned", alias: simplelogin.Alias{Email: "shop@example.com", Pinned: true}, protected: true},
+		{name: "prefix pattern", alias: simplelogin.Alias{Email: "billing@example.com"}, protected: true},
+		{name: "case insensitive", alias: simplelogin.Alias{Email: "me@Bank.Example.com"}, protected: true},
+		{name: "unprotected", alias: simplelogin.Alias{Email: "shop@example.com"}},
+		{name: "partial match", alias: simplelogin.Alias{Email: "me@bank.example.com.evil"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := rules.Check(tt.alias)
+			if errors.Is(err, ErrProtected) != tt.protected {
+				t.Errorf("Check(%s) = %v, want protected %t", tt.alias.Email, err, tt.protected)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	rules, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rules.Check(simplelogin.Alias{Email: "a@example.com", Pinned: true}); err != nil {
+		t.Errorf("Check() without rules = %v, want nil", err)
+	}
+
+	if _, err := New(&config.ProtectConfig{Patterns: []string{"("}}); err == nil {
+		t.Error("New() with an invalid pattern succeeded")
+	}
+}
@@ -0,0 +1,54 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+// aliasCache keeps the alias list in memory so that searches do not page
+// through the API on every request. Writes invalidate it.
+type aliasCache struct {
+	client *simplelogin.Client
+	ttl    time.Duration
+
+	mu        sync.Mutex
+	aliases   []simplelogin.Alias
+	fetchedAt time.Time
+}
+
+func newAliasCache(client *simplelogin.Client, ttl time.Duration) *aliasCache {
+	return &aliasCache{client: client, ttl: ttl}
+}
+
+// get returns the cached aliases, refreshing them when stale
+func (c *aliasCache) get() ([]simplelogin.Alias, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.aliases != nil && time.Since(c.fetchedAt) < c.ttl {
+		return c.aliases, nil
+	}
+
+	aliases, err := c.client.GetAllAliases(simplelogin.AliasListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if aliases == nil {
+		aliases = []simplelogin.Alias{}
+	}
+
+	c.aliases = aliases
+	c.fetchedAt = time.Now()
+
+	return c.aliases, nil
+}
+
+// invalidate drops the cached aliases
+func (c *aliasCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.aliases = nil
+}
@@ -0,0 +1,226 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juli3nk/simplelogin-cli/internal/site"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+// Options configures the daemon
+type Options struct {
+	// CacheTTL is how long the alias list is served from memory
+	CacheTTL time.Duration
+	// AccessLog receives one line per request, when set
+	AccessLog *log.Logger
+}
+
+// Server exposes alias operations over a small authenticated REST API,
+// sharing one API client between local tools
+type Server struct {
+	client *simplelogin.Client
+	tokens *TokenStore
+	cache  *aliasCache
+	logger *log.Logger
+}
+
+// New returns a server backed by client, accepting the tokens of store
+func New(client *simplelogin.Client, store *TokenStore, opts Options) *Server {
+	return &Server{
+		client: client,
+		tokens: store,
+		cache:  newAliasCache(client, opts.CacheTTL),
+		logger: opts.AccessLog,
+	}
+}
+
+// CreateAliasRequest is the body of POST /v1/aliases
+type CreateAliasRequest struct {
+	Hostname string `json:"hostname"`
+	Mode     string `json:"mode,omitempty"`
+	Note     string `json:"note,omitempty"`
+	// New creates an alias even when the site already has one
+	New bool `json:"new,omitempty"`
+}
+
+// CreateAliasResponse is the response of POST /v1/aliases
+type CreateAliasResponse struct {
+	Alias   *simplelogin.Alias `json:"alias"`
+	Created bool               `json:"created"`
+}
+
+// Handler returns the HTTP handler of the API
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /v1/health", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.Handle("GET /v1/aliases", s.authenticated(s.searchAliases))
+	mux.Handle("POST /v1/aliases", s.authenticated(s.createAlias))
+	mux.Handle("POST /v1/aliases/{id}/toggle", s.authenticated(s.toggleAlias))
+
+	return s.logRequests(mux)
+}
+
+// searchAliases lists aliases matching ?query= (email or note) and ?site=
+func (s *Server) searchAliases(w http.ResponseWriter, r *http.Request) {
+	aliases, err := s.cache.get()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if rawURL := r.URL.Query().Get("site"); rawURL != "" {
+		domain, err := site.Normalize(rawURL)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+			return
+		}
+		aliases = site.Match(aliases, domain)
+	}
+
+	query := strings.ToLower(r.URL.Query().Get("query"))
+	result := []simplelogin.Alias{}
+	for _, alias := range aliases {
+		if query == "" || strings.Contains(strings.ToLower(alias.Email), query) || strings.Contains(strings.ToLower(alias.Note), query) {
+			result = append(result, alias)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]simplelogin.Alias{"aliases": result})
+}
+
+// createAlias returns the enabled alias of a hostname, creating one when needed
+func (s *Server) createAlias(w http.ResponseWriter, r *http.Request) {
+	var req CreateAliasRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid request body: " + err.Error()})
+		return
+	}
+
+	domain, err := site.Normalize(req.Hostname)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: err.Error()})
+		return
+	}
+
+	if !req.New {
+		aliases, err := s.cache.get()
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if matches := site.Match(aliases, domain); len(matches) > 0 && matches[0].Enabled {
+			writeJSON(w, http.StatusOK, CreateAliasResponse{Alias: &matches[0]})
+			return
+		}
+	}
+
+	alias, err := site.Create(s.client, domain, req.Mode, req.Note)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.cache.invalidate()
+
+	writeJSON(w, http.StatusCreated, CreateAliasResponse{Alias: alias, Created: true})
+}
+
+func (s *Server) toggleAlias(w http.ResponseWriter, r *http.Request) {
+	aliasID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Error: "invalid alias ID"})
+		return
+	}
+
+	result, err := s.client.ToggleAlias(aliasID)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	s.cache.invalidate()
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// authenticated checks the bearer token and records the client name for the access log
+func (s *Server) authenticated(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		name, valid := "", false
+		if ok {
+			name, valid = s.tokens.Verify(strings.TrimSpace(token))
+		}
+		if !valid {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="simplelogin-cli"`)
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Error: "invalid or missing token"})
+			return
+		}
+
+		if rec, ok := w.(*statusRecorder); ok {
+			rec.client = name
+		}
+		next(w, r)
+	})
+}
+
+// statusRecorder captures the response status and client for the access log
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+	client string
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (s *Server) logRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK, client: "-"}
+		start := time.Now()
+
+		next.ServeHTTP(rec, r)
+
+		if s.logger != nil {
+			s.logger.Printf("%s %s %s %d %s", rec.client, r.Method, r.URL.RequestURI(), rec.status, time.Since(start).Round(time.Millisecond))
+		}
+	})
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// writeError maps API errors to HTTP statuses: client errors are passed
+// through, everything else is a bad gateway
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+
+	var apiErr *simplelogin.APIError
+	var rateErr *simplelogin.RateLimitError
+	switch {
+	case errors.As(err, &rateErr):
+		status = http.StatusTooManyRequests
+		w.Header().Set("Retry-After", strconv.Itoa(rateErr.RetryAfter))
+	case errors.As(err, &apiErr) && apiErr.StatusCode >= 400 && apiErr.StatusCode < 500:
+		status = apiErr.StatusCode
+	}
+
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,132 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+// newTestServer starts a fake SimpleLogin API holding one alias for example.com
+func newTestServer(t *testing.T) (*httptest.Server, string, *atomic.Int32) {
+	t.Helper()
+
+	var listCalls atomic.Int32
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/aliases" && r.URL.Query().Get("page_id") == "0":
+			listCalls.Add(1)
+			w.Write([]byte(`{"aliases":[{"id":1,"email":"shop@example.org","enabled":true,"note":"#site: example.com"}]}`))
+		case r.URL.Path == "/v2/aliases":
+			w.Write([]byte(`{"aliases":[]}`))
+		case r.URL.Path == "/alias/random/new":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":2,"email":"random@example.org","enabled":true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(api.Close)
+
+	client, err := simplelogin.NewClient(&api.URL, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := LoadTokens(filepath.Join(t.TempDir(), "tokens.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := store.Add("browser")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(New(client, store, Options{CacheTTL: time.Minute}).Handler())
+	t.Cleanup(srv.Close)
+
+	return srv, token, &listCalls
+}
+
+func do(t *testing.T, method, url, token, body string) *http.Response {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	return resp
+}
+
+func TestServer_RequiresToken(t *testing.T) {
+	srv, _, _ := newTestServer(t)
+
+	if resp := do(t, http.MethodGet, srv.URL+"/v1/aliases", "", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("no token: status = %d", resp.StatusCode)
+	}
+	if resp := do(t, http.MethodGet, srv.URL+"/v1/aliases", "slc_wrong", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("wrong token: status = %d", resp.StatusCode)
+	}
+	if resp := do(t, http.MethodGet, srv.URL+"/v1/health", "", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("health: status = %d", resp.StatusCode)
+	}
+}
+
+func TestServer_SearchUsesCache(t *testing.T) {
+	srv, token, listCalls := newTestServer(t)
+
+	for i := 0; i < 2; i++ {
+		resp := do(t, http.MethodGet, srv.URL+"/v1/aliases?query=shop", token, "")
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d", resp.StatusCode)
+		}
+
+		var result struct {
+			Aliases []simplelogin.Alias `json:"aliases"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Aliases) != 1 || result.Aliases[0].Email != "shop@example.org" {
+			t.Errorf("aliases = %+v", result.Aliases)
+		}
+	}
+
+	if calls := listCalls.Load(); calls != 1 {
+		t.Errorf("alias list fetched %d times, want 1", calls)
+	}
+}
+
+func TestServer_CreateAlias(t *testing.T) {
+	srv, token, _ := newTestServer(t)
+
+	resp := do(t, http.MethodPost, srv.URL+"/v1/aliases", token, `{"hostname":"https://www.example.com/login"}`)
+	var existing CreateAliasResponse
+	json.NewDecoder(resp.Body).Decode(&existing)
+	if resp.StatusCode != http.StatusOK || existing.Created || existing.Alias.ID != 1 {
+		t.Errorf("existing site: status = %d, response = %+v", resp.StatusCode, existing)
+	}
+
+	resp = do(t, http.MethodPost, srv.URL+"/v1/aliases", token, `{"hostname":"other.net"}`)
+	var created CreateAliasResponse
+	json.NewDecoder(resp.Body).Decode(&created)
+	if resp.StatusCode != http.StatusCreated || !created.Created || created.Alias.ID != 2 {
+		t.Errorf("new site: status = %d, response = %+v", resp.StatusCode, created)
+	}
+}
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+)
+
+// tokenPrefix makes tokens recognisable in logs and secret scanners
+const tokenPrefix = "slc_"
+
+// Token is a client token; only its SHA-256 hash is stored
+type Token struct {
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TokenStore holds the client tokens allowed to call the daemon. Verify
+// reads the file again when it changed, so that tokens added or removed
+// while the daemon runs take effect immediately.
+type TokenStore struct {
+	Tokens []Token `json:"tokens"`
+
+	path string
+
+	mu sync.Mutex
+	// modTime is the modification time of the file last read or written,
+	// zero when there was no file
+	modTime time.Time
+}
+
+// TokensPath returns the default token file of a profile
+func TokensPath(profile string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "simplelogin-cli", "serve-tokens-"+profile+".json"), nil
+}
+
+// LoadTokens reads the token file, an absent file holding no tokens
+func LoadTokens(path string) (*TokenStore, error) {
+	store := &TokenStore{path: path}
+
+	info, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := store.read(info.ModTime()); err != nil {
+		return nil, err
+	}
+
+	return store, nil
+}
+
+// read replaces the tokens with the content of the file
+func (s *TokenStore) read(modTime time.Time) error {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	var file TokenStore
+	if err := json.Unmarshal(data, &file); err != nil {
+		return fmt.Errorf("invalid token file %s: %w", s.path, err)
+	}
+
+	s.Tokens = file.Tokens
+	s.modTime = modTime
+	return nil
+}
+
+// refresh reads the file again when it changed since it was last read or written
+func (s *TokenStore) refresh() error {
+	info, err := os.Stat(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		// A deleted file revokes every token
+		if !s.modTime.IsZero() {
+			s.Tokens = nil
+			s.modTime = time.Time{}
+		}
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+	return s.read(info.ModTime())
+}
+
+// Add creates a token for a client and returns it; it cannot be shown again
+func (s *TokenStore) Add(name string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, token := range s.Tokens {
+		if token.Name == name {
+			return "", fmt.Errorf("a token named %q already exists", name)
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	token := tokenPrefix + hex.EncodeToString(secret)
+
+	s.Tokens = append(s.Tokens, Token{Name: name, Hash: hashToken(token), CreatedAt: time.Now().UTC().Truncate(time.Second)})
+	sort.Slice(s.Tokens, func(i, j int) bool { return s.Tokens[i].Name < s.Tokens[j].Name })
+
+	return token, nil
+}
+
+// Remove deletes the token of a client
+func (s *TokenStore) Remove(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, token := range s.Tokens {
+		if token.Name == name {
+			s.Tokens = append(s.Tokens[:i], s.Tokens[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("no token named %q", name)
+}
+
+// Verify returns the client name of a token. An unreadable token file
+// rejects every token.
+func (s *TokenStore) Verify(token string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.refresh(); err != nil {
+		return "", false
+	}
+
+	hash := hashToken(token)
+	for _, t := range s.Tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Hash), []byte(hash)) == 1 {
+			return t.Name, true
+		}
+	}
+	return "", false
+}
+
+// Save writes the token file, readable by its owner only
+func (s *TokenStore) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+
+	if err := config.WriteFileAtomic(s.path, data); err != nil {
+		return err
+	}
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+	s.modTime = info.ModTime()
+	return nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
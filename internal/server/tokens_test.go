@@ -0,0 +1,43 @@
+package server
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTokenStore_ReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.json")
+
+	// The daemon loads the store once, the CLI edits the file afterwards
+	daemon, err := LoadTokens(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cli, err := LoadTokens(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := cli.Add("browser")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if name, ok := daemon.Verify(token); !ok || name != "browser" {
+		t.Errorf("Verify() of an added token = %q, %v, want browser, true", name, ok)
+	}
+
+	if err := cli.Remove("browser"); err != nil {
+		t.Fatal(err)
+	}
+	if err := cli.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := daemon.Verify(token); ok {
+		t.Error("Verify() accepted a removed token")
+	}
+}
@@ -0,0 +1,82 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/juli3nk/simplelogin-cli/internal/notemeta"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+	"golang.org/x/net/publicsuffix"
+)
+
+// NoteKey is the note metadata key recording the website an alias is used on
+const NoteKey = "site"
+
+// Normalize reduces a URL or hostname to its registrable domain using the
+// embedded public suffix list, e.g. https://shop.example.co.uk/login -> example.co.uk
+func Normalize(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	if rawURL == "" {
+		return "", errors.New("empty URL")
+	}
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL: %w", err)
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return "", fmt.Errorf("no hostname in %q", rawURL)
+	}
+
+	if net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+		return host, nil
+	}
+
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return "", fmt.Errorf("cannot determine registrable domain of %s: %w", host, err)
+	}
+
+	return domain, nil
+}
+
+// Find returns the aliases tied to a registrable domain, enabled ones first
+func Find(client *simplelogin.Client, domain string) ([]simplelogin.Alias, error) {
+	aliases, err := client.GetAllAliases(simplelogin.AliasListOptions{Query: domain})
+	if err != nil {
+		return nil, err
+	}
+
+	return Match(aliases, domain), nil
+}
+
+// Match returns the aliases tied to a registrable domain, enabled ones first
+func Match(aliases []simplelogin.Alias, domain string) []simplelogin.Alias {
+	var enabled, disabled []simplelogin.Alias
+	for _, alias := range aliases {
+		if value, ok := notemeta.Get(alias.Note, NoteKey); !ok || !strings.EqualFold(value, domain) {
+			continue
+		}
+		if alias.Enabled {
+			enabled = append(enabled, alias)
+		} else {
+			disabled = append(disabled, alias)
+		}
+	}
+
+	return append(enabled, disabled...)
+}
+
+// Create creates a random alias for a registrable domain and records the
+// domain in its note. An empty mode uses the account's alias generator.
+func Create(client *simplelogin.Client, domain, mode, note string) (*simplelogin.Alias, error) {
+	return client.CreateRandomAlias(domain, mode, notemeta.Set(note, NoteKey, domain))
+}
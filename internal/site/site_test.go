@@ -0,0 +1,33 @@
+package site
+
+import (
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "https://shop.example.co.uk/login", want: "example.co.uk"},
+		{input: "shop.example.com", want: "example.com"},
+		{input: "HTTP://WWW.Example.COM:8080/path?q=1", want: "example.com"},
+		{input: "https://user.github.io/repo", want: "user.github.io"},
+		{input: "http://localhost:3000", want: "localhost"},
+		{input: "http://192.168.1.1/admin", want: "192.168.1.1"},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Normalize(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Normalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Normalize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package snapshot
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Change kinds
+const (
+	Added   = "added"
+	Removed = "removed"
+	Changed = "changed"
+)
+
+// Change is a difference between two snapshots
+type Change struct {
+	Kind    string `json:"kind"`
+	Object  string `json:"object"`
+	Subject string `json:"subject"`
+	Field   string `json:"field,omitempty"`
+	Old     string `json:"old,omitempty"`
+	New     string `json:"new,omitempty"`
+}
+
+// Diff lists the changes from snapshot a to snapshot b
+func Diff(a, b *Snapshot) []Change {
+	var changes []Change
+
+	// Contacts are only compared when both snapshots include them
+	contacts := a.Contacts && b.Contacts
+	diffKeyed(&changes, "alias", a.Aliases, b.Aliases, func(alias Alias) string { return alias.Email },
+		func(changes *[]Change, a, b Alias) { diffAlias(changes, a, b, contacts) })
+	diffKeyed(&changes, "mailbox", a.Mailboxes, b.Mailboxes, func(mailbox Mailbox) string { return mailbox.Email }, diffMailbox)
+	diffKeyed(&changes, "domain", a.Domains, b.Domains, func(domain Domain) string { return domain.Name }, diffDomain)
+
+	if a.Settings != nil && b.Settings != nil {
+		add := fieldDiff(&changes, "setting", "account")
+		add("alias_generator", a.Settings.AliasGenerator, b.Settings.AliasGenerator)
+		add("notification", a.Settings.Notification, b.Settings.Notification)
+		add("random_alias_default_domain", a.Settings.RandomAliasDefaultDomain, b.Settings.RandomAliasDefaultDomain)
+		add("sender_format", a.Settings.SenderFormat, b.Settings.SenderFormat)
+		add("random_alias_suffix", a.Settings.RandomAliasSuffix, b.Settings.RandomAliasSuffix)
+	}
+
+	return changes
+}
+
+// diffKeyed matches two lists by key and reports added, removed and changed items
+func diffKeyed[T any](changes *[]Change, object string, a, b []T, key func(T) string, diff func(*[]Change, T, T)) {
+	old := make(map[string]T, len(a))
+	for _, item := range a {
+		old[key(item)] = item
+	}
+
+	seen := make(map[string]bool, len(b))
+	for _, item := range b {
+		k := key(item)
+		seen[k] = true
+
+		if previous, ok := old[k]; ok {
+			diff(changes, previous, item)
+		} else {
+			*changes = append(*changes, Change{Kind: Added, Object: object, Subject: k})
+		}
+	}
+
+	for _, item := range a {
+		if k := key(item); !seen[k] {
+			*changes = append(*changes, Change{Kind: Removed, Object: object, Subject: k})
+		}
+	}
+}
+
+func diffAlias(changes *[]Change, a, b Alias, contacts bool) {
+	add := fieldDiff(changes, "alias", b.Email)
+	add("enabled", a.Enabled, b.Enabled)
+	add("pinned", a.Pinned, b.Pinned)
+	add("name", a.Name, b.Name)
+	add("note", a.Note, b.Note)
+	add("mailboxes", strings.Join(a.Mailboxes, ","), strings.Join(b.Mailboxes, ","))
+
+	if !contacts {
+		return
+	}
+	diffKeyed(changes, "contact", a.Contacts, b.Contacts,
+		func(contact Contact) string { return b.Email + " <- " + contact.Email },
+		func(changes *[]Change, a, c Contact) {
+			fieldDiff(changes, "contact", b.Email+" <- "+c.Email)("blocked", a.Blocked, c.Blocked)
+		})
+}
+
+func diffMailbox(changes *[]Change, a, b Mailbox) {
+	add := fieldDiff(changes, "mailbox", b.Email)
+	add("default", a.Default, b.Default)
+	add("verified", a.Verified, b.Verified)
+}
+
+func diffDomain(changes *[]Change, a, b Domain) {
+	add := fieldDiff(changes, "domain", b.Name)
+	add("verified", a.Verified, b.Verified)
+	add("catch_all", a.CatchAll, b.CatchAll)
+	add("random_prefix_generation", a.RandomPrefixGeneration, b.RandomPrefixGeneration)
+	add("display_name", a.DisplayName, b.DisplayName)
+	if !slices.Equal(a.Mailboxes, b.Mailboxes) {
+		add("mailboxes", strings.Join(a.Mailboxes, ","), strings.Join(b.Mailboxes, ","))
+	}
+}
+
+// fieldDiff returns a function recording a changed field of one object
+func fieldDiff(changes *[]Change, object, subject string) func(field string, a, b any) {
+	return func(field string, a, b any) {
+		before, after := fmt.Sprint(a), fmt.Sprint(b)
+		if before != after {
+			*changes = append(*changes, Change{Kind: Changed, Object: object, Subject: subject, Field: field, Old: before, New: after})
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package snapshot
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+func TestDiff(t *testing.T) {
+	a := &Snapshot{
+		Contacts: true,
+		Aliases: []Alias{
+			{Email: "gone@example.com", Enabled: true, Mailboxes: []string{"me@example.org"}},
+			{Email: "shared@example.com", Enabled: true, Note: "team", Mailboxes: []string{"me@example.org"},
+				Contacts: []Contact{{Email: "shop@example.net"}}},
+		},
+		Mailboxes: []Mailbox{{Email: "me@example.org", Default: true, Verified: true}},
+		Settings:  &simplelogin.Setting{AliasGenerator: "word"},
+	}
+	b := &Snapshot{
+		Contacts: true,
+		Aliases: []Alias{
+			{Email: "new@example.com", Enabled: true, Mailboxes: []string{"me@example.org"}},
+			{Email: "shared@example.com", Enabled: false, Note: "team", Mailboxes: []string{"ops@example.org"},
+				Contacts: []Contact{{Email: "shop@example.net", Blocked: true}}},
+		},
+		Mailboxes: []Mailbox{{Email: "me@example.org", Default: true, Verified: true}},
+		Settings:  &simplelogin.Setting{AliasGenerator: "uuid"},
+	}
+
+	want := []Change{
+		{Kind: Added, Object: "alias", Subject: "new@example.com"},
+		{Kind: Changed, Object: "alias", Subject: "shared@example.com", Field: "enabled", Old: "true", New: "false"},
+		{Kind: Changed, Object: "alias", Subject: "shared@example.com", Field: "mailboxes", Old: "me@example.org", New: "ops@example.org"},
+		{Kind: Changed, Object: "contact", Subject: "shared@example.com <- shop@example.net", Field: "blocked", Old: "false", New: "true"},
+		{Kind: Removed, Object: "alias", Subject: "gone@example.com"},
+		{Kind: Changed, Object: "setting", Subject: "account", Field: "alias_generator", Old: "word", New: "uuid"},
+	}
+
+	if got := Diff(a, b); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if got := Diff(a, a); len(got) != 0 {
+		t.Errorf("Diff() of identical snapshots = %+v", got)
+	}
+}
+
+func TestDiff_FirstAndLastContact(t *testing.T) {
+	none := &Snapshot{Contacts: true, Aliases: []Alias{{Email: "a@example.com", Contacts: []Contact{}}}}
+	one := &Snapshot{Contacts: true, Aliases: []Alias{{Email: "a@example.com", Contacts: []Contact{{Email: "shop@example.net"}}}}}
+
+	added := []Change{{Kind: Added, Object: "contact", Subject: "a@example.com <- shop@example.net"}}
+	if got := Diff(none, one); !reflect.DeepEqual(got, added) {
+		t.Errorf("Diff() of the first contact = %+v, want %+v", got, added)
+	}
+
+	removed := []Change{{Kind: Removed, Object: "contact", Subject: "a@example.com <- shop@example.net"}}
+	if got := Diff(one, none); !reflect.DeepEqual(got, removed) {
+		t.Errorf("Diff() of the last contact = %+v, want %+v", got, removed)
+	}
+
+	// Contacts are not compared when one snapshot was taken without them
+	without := &Snapshot{Aliases: []Alias{{Email: "a@example.com"}}}
+	if got := Diff(without, one); len(got) != 0 {
+		t.Errorf("Diff() without contacts = %+v", got)
+	}
+}
+
+func TestSaveLoad_NoContacts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snap.json")
+
+	snap := &Snapshot{Version: Version, Contacts: true, Aliases: []Alias{{Email: "a@example.com", Contacts: []Contact{}}}}
+	if err := snap.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Contacts || loaded.Aliases[0].Contacts == nil {
+		t.Errorf("Load() lost the contacts of an alias without any: %+v", loaded)
+	}
+}
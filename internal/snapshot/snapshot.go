@@ -0,0 +1,221 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juli3nk/simplelogin-cli/internal/config"
+	"github.com/juli3nk/simplelogin-cli/pkg/simplelogin"
+)
+
+// Version is the format version of the snapshots written by Take
+const Version = 1
+
+// Snapshot is a normalised copy of an account: volatile fields such as
+// counters and latest activity are left out and lists are sorted, so that
+// two snapshots only differ when the account changed
+type Snapshot struct {
+	Version int       `json:"version"`
+	TakenAt time.Time `json:"taken_at"`
+	Account string    `json:"account"`
+	// Contacts is set when the contacts of every alias were fetched
+	Contacts  bool                 `json:"contacts,omitempty"`
+	Aliases   []Alias              `json:"aliases"`
+	Mailboxes []Mailbox            `json:"mailboxes"`
+	Domains   []Domain             `json:"domains"`
+	Settings  *simplelogin.Setting `json:"settings,omitempty"`
+}
+
+// Alias is the normalised state of an alias
+type Alias struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name,omitempty"`
+	Note      string    `json:"note,omitempty"`
+	Enabled   bool      `json:"enabled"`
+	Pinned    bool      `json:"pinned,omitempty"`
+	Mailboxes []string  `json:"mailboxes"`
+	Contacts  []Contact `json:"contacts"`
+}
+
+// Contact is the normalised state of an alias contact
+type Contact struct {
+	Email   string `json:"email"`
+	Blocked bool   `json:"blocked,omitempty"`
+}
+
+// Mailbox is the normalised state of a mailbox
+type Mailbox struct {
+	ID       int    `json:"id"`
+	Email    string `json:"email"`
+	Default  bool   `json:"default,omitempty"`
+	Verified bool   `json:"verified"`
+}
+
+// Domain is the normalised state of a custom domain
+type Domain struct {
+	ID                     int      `json:"id"`
+	Name                   string   `json:"name"`
+	DisplayName            string   `json:"display_name,omitempty"`
+	Verified               bool     `json:"verified"`
+	CatchAll               bool     `json:"catch_all,omitempty"`
+	RandomPrefixGeneration bool     `json:"random_prefix_generation,omitempty"`
+	Mailboxes              []string `json:"mailboxes"`
+}
+
+// Options selects what Take includes
+type Options struct {
+	// Contacts fetches the contacts of every alias, one request per alias
+	Contacts bool
+	// Filter keeps only the aliases it returns true for, when set
+	Filter func(simplelogin.Alias) bool
+}
+
+// Take reads the account and returns its normalised snapshot
+func Take(client *simplelogin.Client, opts Options) (*Snapshot, error) {
+	user, err := client.GetUserInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	snap := &Snapshot{
+		Version:  Version,
+		TakenAt:  time.Now().UTC().Truncate(time.Second),
+		Account:  user.Email,
+		Contacts: opts.Contacts,
+	}
+
+	aliases, err := client.GetAllAliases(simplelogin.AliasListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, alias := range aliases {
+		if opts.Filter != nil && !opts.Filter(alias) {
+			continue
+		}
+
+		record := Alias{
+			ID:        alias.ID,
+			Email:     strings.ToLower(alias.Email),
+			Name:      alias.Name,
+			Note:      alias.Note,
+			Enabled:   alias.Enabled,
+			Pinned:    alias.Pinned,
+			Mailboxes: mailboxEmails(alias.Mailboxes),
+		}
+
+		if opts.Contacts {
+			record.Contacts = []Contact{}
+
+			contacts, err := client.GetAllAliasContacts(alias.ID)
+			if err != nil {
+				return nil, fmt.Errorf("contacts of %s: %w", alias.Email, err)
+			}
+			for _, contact := range contacts {
+				record.Contacts = append(record.Contacts, Contact{Email: strings.ToLower(contact.Contact), Blocked: contact.BlockForward})
+			}
+			sort.Slice(record.Contacts, func(i, j int) bool { return record.Contacts[i].Email < record.Contacts[j].Email })
+		}
+
+		snap.Aliases = append(snap.Aliases, record)
+	}
+	sort.Slice(snap.Aliases, func(i, j int) bool { return snap.Aliases[i].Email < snap.Aliases[j].Email })
+
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		return nil, err
+	}
+	for _, mailbox := range mailboxes {
+		snap.Mailboxes = append(snap.Mailboxes, Mailbox{
+			ID:       mailbox.ID,
+			Email:    strings.ToLower(mailbox.Email),
+			Default:  mailbox.Default,
+			Verified: mailbox.Verified,
+		})
+	}
+	sort.Slice(snap.Mailboxes, func(i, j int) bool { return snap.Mailboxes[i].Email < snap.Mailboxes[j].Email })
+
+	domains, err := client.GetDomains()
+	if err != nil {
+		return nil, err
+	}
+	for _, domain := range domains {
+		snap.Domains = append(snap.Domains, Domain{
+			ID:                     domain.ID,
+			Name:                   strings.ToLower(domain.DomainName),
+			DisplayName:            domain.Name,
+			Verified:               domain.IsVerified,
+			CatchAll:               domain.CatchAll,
+			RandomPrefixGeneration: domain.RandomPrefixGeneration,
+			Mailboxes:              mailboxEmails(domain.Mailboxes),
+		})
+	}
+	sort.Slice(snap.Domains, func(i, j int) bool { return snap.Domains[i].Name < snap.Domains[j].Name })
+
+	if snap.Settings, err = client.GetSetting(); err != nil {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+func mailboxEmails(mailboxes []simplelogin.Mailbox) []string {
+	emails := make([]string, len(mailboxes))
+	for i, mailbox := range mailboxes {
+		emails[i] = strings.ToLower(mailbox.Email)
+	}
+	sort.Strings(emails)
+	return emails
+}
+
+// Load reads a snapshot file
+func Load(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	if snap.Version > Version {
+		return nil, fmt.Errorf("snapshot %s has version %d, this version supports up to %d", path, snap.Version, Version)
+	}
+
+	// Older snapshots only carry contacts, without the flag
+	for _, alias := range snap.Aliases {
+		snap.Contacts = snap.Contacts || alias.Contacts != nil
+	}
+
+	return &snap, nil
+}
+
+// Save writes the snapshot to path, creating its directory
+func (s *Snapshot) Save(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := s.Write(&buf); err != nil {
+		return err
+	}
+
+	return config.WriteFileAtomic(path, buf.Bytes())
+}
+
+// Write writes the snapshot as indented JSON
+func (s *Snapshot) Write(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
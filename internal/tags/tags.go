@@ -0,0 +1,79 @@
+package tags
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/juli3nk/simplelogin-cli/internal/notemeta"
+)
+
+// NoteKey is the note metadata key holding the comma separated alias tags
+const NoteKey = "tags"
+
+// Normalize lowercases and trims a tag, rejecting characters that would
+// break the "#tags: a,b" line
+func Normalize(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", fmt.Errorf("empty tag")
+	}
+	if strings.ContainsAny(tag, ",\n\r") {
+		return "", fmt.Errorf("invalid tag %q: commas and newlines are not allowed", tag)
+	}
+	return tag, nil
+}
+
+// Get returns the tags of a note, sorted
+func Get(note string) []string {
+	value, ok := notemeta.Get(note, NoteKey)
+	if !ok {
+		return nil
+	}
+
+	var result []string
+	for _, tag := range strings.Split(value, ",") {
+		if tag, err := Normalize(tag); err == nil && !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+// Set replaces the tags of a note, removing the tags line when empty
+func Set(note string, tags []string) string {
+	if len(tags) == 0 {
+		return notemeta.Delete(note, NoteKey)
+	}
+
+	tags = slices.Clone(tags)
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+
+	return notemeta.Set(note, NoteKey, strings.Join(tags, ","))
+}
+
+// Add adds tags to a note
+func Add(note string, tags ...string) string {
+	return Set(note, append(Get(note), tags...))
+}
+
+// Remove removes tags from a note
+func Remove(note string, tags ...string) string {
+	return Set(note, slices.DeleteFunc(Get(note), func(tag string) bool {
+		return slices.Contains(tags, tag)
+	}))
+}
+
+// HasAll reports whether the note carries every given tag
+func HasAll(note string, tags []string) bool {
+	current := Get(note)
+	for _, tag := range tags {
+		if !slices.Contains(current, strings.ToLower(strings.TrimSpace(tag))) {
+			return false
+		}
+	}
+	return true
+}
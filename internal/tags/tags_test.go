@@ -0,0 +1,39 @@
+package tags
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAddRemove(t *testing.T) {
+	note := "Newsletter account\n#site: example.com"
+
+	note = Add(note, "work", "shopping", "work")
+	if note != "Newsletter account\n#site: example.com\n#tags: shopping,work" {
+		t.Errorf("Add() = %q", note)
+	}
+
+	if got := Get(note); !slices.Equal(got, []string{"shopping", "work"}) {
+		t.Errorf("Get() = %v", got)
+	}
+
+	if !HasAll(note, []string{"Work"}) || HasAll(note, []string{"work", "travel"}) {
+		t.Error("HasAll() returned a wrong result")
+	}
+
+	note = Remove(note, "shopping", "work")
+	if note != "Newsletter account\n#site: example.com" {
+		t.Errorf("Remove() = %q", note)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	if tag, err := Normalize("  Work "); err != nil || tag != "work" {
+		t.Errorf("Normalize() = %q, %v", tag, err)
+	}
+	for _, tag := range []string{"", "a,b", "a\nb"} {
+		if _, err := Normalize(tag); err == nil {
+			t.Errorf("Normalize(%q) returned no error", tag)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // AliasOptions represents available options for creating aliases
@@ -22,6 +23,32 @@ type AliasOptionsSuffix struct {
 	IsPremium    bool   `json:"is_premium"`
 }
 
+// MatchSuffix finds the suffix a full alias address ends with and returns the
+// remaining prefix. When several suffixes match, the longest one wins.
+func (o *AliasOptions) MatchSuffix(email string) (string, *AliasOptionsSuffix, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	var match *AliasOptionsSuffix
+	for i := range o.Suffixes {
+		suffix := &o.Suffixes[i]
+		if suffix.Suffix == "" || !strings.HasSuffix(email, strings.ToLower(suffix.Suffix)) {
+			continue
+		}
+		if len(email) == len(suffix.Suffix) {
+			continue
+		}
+		if match == nil || len(suffix.Suffix) > len(match.Suffix) {
+			match = suffix
+		}
+	}
+
+	if match == nil {
+		return "", nil, &ValidationError{Field: "email", Message: fmt.Sprintf("no available suffix matches %s", email)}
+	}
+
+	return email[:len(email)-len(match.Suffix)], match, nil
+}
+
 type AliasCreateCustomOptions struct {
 	Hostname     string `json:"hostname"`
 	AliasPrefix  string `json:"alias_prefix"`
@@ -91,13 +118,14 @@ type AliasActivitiesResponse struct {
 	Activities []AliasActivity `json:"activities"`
 }
 
+// AliasUpdateOptions holds the fields to update, nil and empty fields are left unchanged
 type AliasUpdateOptions struct {
-	Note       string `json:"note"`
-	MailboxID  int    `json:"mailbox_id"`
-	Name       string `json:"name"`
-	MailboxIDs []int  `json:"mailbox_ids"`
-	DisablePGP bool   `json:"disable_pgp"`
-	Pinned     bool   `json:"pinned"`
+	Note       *string `json:"note,omitempty"`
+	MailboxID  int     `json:"mailbox_id,omitempty"`
+	Name       *string `json:"name,omitempty"`
+	MailboxIDs []int   `json:"mailbox_ids,omitempty"`
+	DisablePGP *bool   `json:"disable_pgp,omitempty"`
+	Pinned     *bool   `json:"pinned,omitempty"`
 }
 
 type AliasContact struct {
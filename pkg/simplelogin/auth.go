@@ -16,12 +16,46 @@ type LoginRequest struct {
 
 // LoginResponse represents the login response
 type LoginResponse struct {
-	APIKey string `json:"api_key"`
+	Name       string  `json:"name"`
+	Email      string  `json:"email"`
+	MFAEnabled bool    `json:"mfa_enabled"`
+	MFAKey     *string `json:"mfa_key"`
+	APIKey     string  `json:"api_key"`
 }
 
-// Login authenticates a user and returns an API key
+// RegisterRequest represents the registration request payload
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// ActivateRequest represents the account activation request payload
+type ActivateRequest struct {
+	Email string `json:"email"`
+	Code  string `json:"code"`
+}
+
+// AuthMessageResponse represents the informational response returned by the /auth endpoints
+type AuthMessageResponse struct {
+	Msg string `json:"msg"`
+}
+
+// Login authenticates a user against the hosted instance and returns an API key
 func Login(email, password, device string) (string, error) {
-	client := &http.Client{}
+	result, err := NewAnonymousClient(nil).Login(email, password, device)
+	if err != nil {
+		return "", err
+	}
+
+	return result.APIKey, nil
+}
+
+// Login authenticates a user
+// When MFA is enabled, the returned APIKey is empty and MFAKey must be used to finish the login
+func (c *Client) Login(email, password, device string) (*LoginResponse, error) {
+	if email == "" {
+		return nil, &ValidationError{Field: "email", Message: "email is required"}
+	}
 
 	data := LoginRequest{
 		Email:    email,
@@ -29,25 +63,122 @@ func Login(email, password, device string) (string, error) {
 		Device:   device,
 	}
 
-	jsonData, err := json.Marshal(data)
+	var result LoginResponse
+	if err := c.postAuth("/auth/login", data, &result); err != nil {
+		return nil, fmt.Errorf("login failed: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Register creates a new account; an activation code is sent by email
+func (c *Client) Register(email, password string) (*AuthMessageResponse, error) {
+	if email == "" {
+		return nil, &ValidationError{Field: "email", Message: "email is required"}
+	}
+	if password == "" {
+		return nil, &ValidationError{Field: "password", Message: "password is required"}
+	}
+
+	var result AuthMessageResponse
+	if err := c.postAuth("/auth/register", RegisterRequest{Email: email, Password: password}, &result); err != nil {
+		return nil, fmt.Errorf("registration failed: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Activate activates a newly registered account with the code received by email
+func (c *Client) Activate(email, code string) (*AuthMessageResponse, error) {
+	if email == "" {
+		return nil, &ValidationError{Field: "email", Message: "email is required"}
+	}
+	if code == "" {
+		return nil, &ValidationError{Field: "code", Message: "activation code is required"}
+	}
+
+	var result AuthMessageResponse
+	if err := c.postAuth("/auth/activate", ActivateRequest{Email: email, Code: code}, &result); err != nil {
+		return nil, fmt.Errorf("activation failed: %w", err)
+	}
+
+	return &result, nil
+}
+
+// Reactivate sends a new activation code to an account that is not activated yet
+func (c *Client) Reactivate(email string) (*AuthMessageResponse, error) {
+	if email == "" {
+		return nil, &ValidationError{Field: "email", Message: "email is required"}
+	}
+
+	var result AuthMessageResponse
+	if err := c.postAuth("/auth/reactivate", map[string]string{"email": email}, &result); err != nil {
+		return nil, fmt.Errorf("reactivation failed: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ForgotPassword requests a password reset email
+// The server answers successfully even when the email is unknown
+func (c *Client) ForgotPassword(email string) error {
+	if email == "" {
+		return &ValidationError{Field: "email", Message: "email is required"}
+	}
+
+	if err := c.postAuth("/auth/forgot_password", map[string]string{"email": email}, nil); err != nil {
+		return fmt.Errorf("password reset request failed: %w", err)
+	}
+
+	return nil
+}
+
+// postAuth sends a JSON payload to one of the /auth endpoints
+func (c *Client) postAuth(endpoint string, payload interface{}, v interface{}) error {
+	jsonData, err := json.Marshal(payload)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal login data: %w", err)
+		return fmt.Errorf("failed to marshal auth data: %w", err)
 	}
 
-	resp, err := client.Post(BaseURL+"/auth/login", "application/json", bytes.NewBuffer(jsonData))
+	resp, err := c.doRequest(http.MethodPost, endpoint, bytes.NewBuffer(jsonData))
 	if err != nil {
-		return "", fmt.Errorf("login request failed: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		body, _ := json.Marshal(resp.Body)
-		return "", fmt.Errorf("login failed (status %d): %s", resp.StatusCode, string(body))
+	return c.handleResponse(resp, v)
+}
+
+// Logout ends the current session on the server
+func (c *Client) Logout() error {
+	resp, err := c.doRequest(http.MethodGet, "/logout", nil)
+	if err != nil {
+		return err
 	}
 
-	var result LoginResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("failed to decode login response: %w", err)
+	return c.handleResponse(resp, nil)
+}
+
+// CreateApiKey creates a new API key for the given device name
+func (c *Client) CreateApiKey(device string) (string, error) {
+	if device == "" {
+		return "", &ValidationError{Field: "device", Message: "device is required"}
+	}
+
+	jsonData, err := json.Marshal(map[string]string{"device": device})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal api key data: %w", err)
+	}
+
+	resp, err := c.doRequest(http.MethodPost, "/api_key", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		APIKey string `json:"api_key"`
+	}
+	if err := c.handleResponse(resp, &result); err != nil {
+		return "", err
 	}
 
 	return result.APIKey, nil
@@ -0,0 +1,60 @@
+package simplelogin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestActivate_ErrorMessage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/auth/activate" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authentication") != "" {
+			t.Error("anonymous client must not send an Authentication header")
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "Wrong email or code"})
+	}))
+	defer server.Close()
+
+	client := NewAnonymousClient(&server.URL)
+
+	_, err := client.Activate("john@example.com", "123456")
+	if err == nil {
+		t.Fatal("Activate() expected an error")
+	}
+	if !strings.Contains(err.Error(), "Wrong email or code") {
+		t.Errorf("Activate() error = %v, want server message", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req RegisterRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		if req.Email != "john@example.com" || req.Password != "secret" {
+			t.Errorf("unexpected payload %+v", req)
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{"msg": "User needs to confirm their account"})
+	}))
+	defer server.Close()
+
+	client := NewAnonymousClient(&server.URL)
+
+	result, err := client.Register("john@example.com", "secret")
+	if err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if result.Msg != "User needs to confirm their account" {
+		t.Errorf("Register() msg = %q", result.Msg)
+	}
+}
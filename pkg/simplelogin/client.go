@@ -22,6 +22,7 @@ type Client struct {
 	apiKey     string
 	httpClient *http.Client
 	logger     *log.Logger
+	limiter    *rateLimiter
 }
 
 // NewClient creates a new SimpleLogin API client with a custom base URL
@@ -51,6 +52,24 @@ func NewClient(baseURL *string, apiKey string) (*Client, error) {
 	return client, nil
 }
 
+// NewAnonymousClient creates a SimpleLogin API client without an API key
+// It can only be used for the unauthenticated /auth endpoints
+// baseURL: The custom base URL for the API, or nil for the hosted instance
+func NewAnonymousClient(baseURL *string) *Client {
+	url := BaseURL
+	if baseURL != nil {
+		url = *baseURL
+	}
+
+	return &Client{
+		baseURL: url,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		logger: log.New(io.Discard, "", 0),
+	}
+}
+
 // SetLogger sets a custom logger for the client
 // This allows for custom logging configuration and output
 // logger: The logger instance to use for client logging
@@ -67,12 +86,20 @@ func (c *Client) doRequest(method, endpoint string, body io.Reader) (*http.Respo
 func (c *Client) doRequestWithContext(ctx context.Context, method, endpoint string, body io.Reader) (*http.Response, error) {
 	url := fmt.Sprintf("%s%s", c.baseURL, endpoint)
 
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Authentication", c.apiKey)
+	if c.apiKey != "" {
+		req.Header.Set("Authentication", c.apiKey)
+	}
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
@@ -1,7 +1,11 @@
 package simplelogin
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -89,3 +93,75 @@ func TestAPIError_Error(t *testing.T) {
 		})
 	}
 }
+
+func TestAliasOptions_MatchSuffix(t *testing.T) {
+	options := &AliasOptions{
+		Suffixes: []AliasOptionsSuffix{
+			{Suffix: "@example.com", SignedSuffix: "custom", IsCustom: true},
+			{Suffix: ".abc@aleeas.com", SignedSuffix: "word"},
+			{Suffix: "@sub.example.com", SignedSuffix: "sub", IsCustom: true},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		email      string
+		wantPrefix string
+		wantSigned string
+		wantErr    bool
+	}{
+		{name: "custom domain", email: "shop-2026@example.com", wantPrefix: "shop-2026", wantSigned: "custom"},
+		{name: "word suffix", email: "shop.abc@aleeas.com", wantPrefix: "shop", wantSigned: "word"},
+		{name: "longest suffix wins", email: "news@sub.example.com", wantPrefix: "news", wantSigned: "sub"},
+		{name: "case insensitive", email: "Shop@Example.com", wantPrefix: "shop", wantSigned: "custom"},
+		{name: "unknown domain", email: "shop@other.com", wantErr: true},
+		{name: "empty prefix", email: "@example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prefix, suffix, err := options.MatchSuffix(tt.email)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MatchSuffix() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if prefix != tt.wantPrefix || suffix.SignedSuffix != tt.wantSigned {
+				t.Errorf("MatchSuffix() = %q, %q, want %q, %q", prefix, suffix.SignedSuffix, tt.wantPrefix, tt.wantSigned)
+			}
+		})
+	}
+}
+
+func TestSetRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&server.URL, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.SetRateLimit(10, time.Second)
+
+	start := time.Now()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetStats(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// 4 requests at 10 per second: the last one waits for 3 intervals
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Errorf("4 rate limited requests took %s, want at least 300ms", elapsed)
+	}
+}
@@ -0,0 +1,76 @@
+package simplelogin
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Notification represents a SimpleLogin notification
+type Notification struct {
+	ID        int    `json:"id"`
+	Message   string `json:"message"`
+	Title     string `json:"title"`
+	Read      bool   `json:"read"`
+	CreatedAt string `json:"created_at"`
+}
+
+// NotificationResponse represents the response for listing notifications
+type NotificationResponse struct {
+	More          bool           `json:"more"`
+	Notifications []Notification `json:"notifications"`
+}
+
+// GetNotifications retrieves a page of notifications
+func (c *Client) GetNotifications(pageID int) (*NotificationResponse, error) {
+	endpoint := fmt.Sprintf("/notifications?page=%d", pageID)
+
+	resp, err := c.doRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var result NotificationResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetAllNotifications retrieves all notifications across all pages
+func (c *Client) GetAllNotifications() ([]Notification, error) {
+	var allNotifications []Notification
+	pageID := 0
+
+	for {
+		result, err := c.GetNotifications(pageID)
+		if err != nil {
+			return nil, err
+		}
+
+		allNotifications = append(allNotifications, result.Notifications...)
+
+		if !result.More || len(result.Notifications) == 0 {
+			break
+		}
+		pageID++
+	}
+
+	return allNotifications, nil
+}
+
+// MarkNotificationRead marks a notification as read
+func (c *Client) MarkNotificationRead(notificationID int) error {
+	if notificationID <= 0 {
+		return &ValidationError{Field: "notificationID", Message: "notification ID must be positive"}
+	}
+
+	endpoint := fmt.Sprintf("/notifications/%d/read", notificationID)
+
+	resp, err := c.doRequest(http.MethodPost, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.handleResponse(resp, nil)
+}
@@ -0,0 +1,73 @@
+package simplelogin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAllNotifications(t *testing.T) {
+	pages := map[string]NotificationResponse{
+		"0": {More: true, Notifications: []Notification{{ID: 1, Title: "first"}, {ID: 2, Title: "second"}}},
+		"1": {More: false, Notifications: []Notification{{ID: 3, Title: "third", Read: true}}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/notifications" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		page, ok := pages[r.URL.Query().Get("page")]
+		if !ok {
+			t.Errorf("unexpected page %q", r.URL.Query().Get("page"))
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&server.URL, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notifications, err := client.GetAllNotifications()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(notifications) != 3 {
+		t.Fatalf("GetAllNotifications() returned %d notifications, want 3", len(notifications))
+	}
+	if notifications[2].Title != "third" || !notifications[2].Read {
+		t.Errorf("last notification = %+v, want the read \"third\" one", notifications[2])
+	}
+}
+
+func TestMarkNotificationRead(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if r.Method != http.MethodPost || r.URL.Path != "/notifications/42/read" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"done": true}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&server.URL, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.MarkNotificationRead(42); err != nil {
+		t.Fatal(err)
+	}
+	if !called {
+		t.Error("MarkNotificationRead() did not call the API")
+	}
+
+	if err := client.MarkNotificationRead(0); err == nil {
+		t.Error("MarkNotificationRead(0) error = nil, want a validation error")
+	}
+}
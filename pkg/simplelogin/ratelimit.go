@@ -0,0 +1,52 @@
+package simplelogin
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateLimiter spaces requests evenly so that a client never sends more than
+// one request per interval, whatever the number of goroutines using it
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// wait blocks until the next request slot is available
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// SetRateLimit limits the client to requests per period, shared by all goroutines
+// A zero value removes the limit
+func (c *Client) SetRateLimit(requests int, per time.Duration) {
+	if requests <= 0 || per <= 0 {
+		c.limiter = nil
+		return
+	}
+
+	c.limiter = &rateLimiter{interval: per / time.Duration(requests)}
+}
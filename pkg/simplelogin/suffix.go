@@ -0,0 +1,163 @@
+package simplelogin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IsSignatureRejected reports whether the API refused a signed suffix because it expired
+func IsSignatureRejected(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusPreconditionFailed
+}
+
+// SuffixResolver turns full alias addresses into a prefix and a signed suffix.
+// The alias options are fetched once and reused for TTL, or until a signature
+// is rejected; a zero TTL reuses them until then.
+type SuffixResolver struct {
+	client   *Client
+	hostname string
+	ttl      time.Duration
+
+	mu        sync.Mutex
+	options   *AliasOptions
+	fetchedAt time.Time
+}
+
+// NewSuffixResolver returns a resolver of the suffixes available for hostname
+func NewSuffixResolver(client *Client, hostname string, ttl time.Duration) *SuffixResolver {
+	return &SuffixResolver{client: client, hostname: hostname, ttl: ttl}
+}
+
+// Resolve returns the prefix and the suffix a full alias address is made of,
+// fetching the alias options again when refresh is set or they are stale
+func (r *SuffixResolver) Resolve(email string, refresh bool) (string, *AliasOptionsSuffix, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.load(refresh); err != nil {
+		return "", nil, err
+	}
+
+	prefix, suffix, err := r.options.MatchSuffix(email)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w (available: %s)", err, r.available())
+	}
+
+	// The caller gets a copy, the options may be refreshed concurrently
+	match := *suffix
+	return prefix, &match, nil
+}
+
+// ResolveSuffix returns the option of exactly this suffix, such as
+// "@example.com" or ".abc@aleeas.com", fetching the alias options again when
+// refresh is set or they are stale
+func (r *SuffixResolver) ResolveSuffix(suffix string, refresh bool) (*AliasOptionsSuffix, error) {
+	return r.find(suffix, refresh, func(option AliasOptionsSuffix) bool {
+		return strings.EqualFold(option.Suffix, suffix)
+	})
+}
+
+// Create creates the custom alias email, fetching a new signed suffix and
+// retrying once when the server rejects the signature
+func (r *SuffixResolver) Create(email string, input AliasCreateCustomOptions) (*Alias, error) {
+	return r.create(input, func(refresh bool) (string, *AliasOptionsSuffix, error) {
+		return r.Resolve(email, refresh)
+	})
+}
+
+// CreateWithSuffix creates the custom alias <prefix><suffix> like Create, for
+// callers that know which suffix the address ends with
+func (r *SuffixResolver) CreateWithSuffix(prefix, suffix string, input AliasCreateCustomOptions) (*Alias, error) {
+	return r.create(input, func(refresh bool) (string, *AliasOptionsSuffix, error) {
+		option, err := r.ResolveSuffix(suffix, refresh)
+		return prefix, option, err
+	})
+}
+
+// CreateWithOption creates the custom alias <prefix><option.Suffix> with the
+// signed suffix of option, such as one picked from the alias options earlier.
+// When the server rejects the signature, the option with the same suffix and
+// premium flag is fetched again and the creation retried once.
+func (r *SuffixResolver) CreateWithOption(prefix string, option AliasOptionsSuffix, input AliasCreateCustomOptions) (*Alias, error) {
+	return r.create(input, func(refresh bool) (string, *AliasOptionsSuffix, error) {
+		if !refresh {
+			return prefix, &option, nil
+		}
+
+		match, err := r.find(option.Suffix, true, func(o AliasOptionsSuffix) bool {
+			return strings.EqualFold(o.Suffix, option.Suffix) && o.IsPremium == option.IsPremium
+		})
+		return prefix, match, err
+	})
+}
+
+// create creates the alias resolved by resolve, resolving it again with
+// refresh set when the server rejects the signature
+func (r *SuffixResolver) create(input AliasCreateCustomOptions, resolve func(refresh bool) (string, *AliasOptionsSuffix, error)) (*Alias, error) {
+	for attempt := 0; ; attempt++ {
+		prefix, suffix, err := resolve(attempt > 0)
+		if err != nil {
+			return nil, err
+		}
+
+		input.AliasPrefix = prefix
+		input.SignedSuffix = suffix.SignedSuffix
+
+		alias, err := r.client.CreateCustomAlias(r.hostname, input)
+		if err != nil && attempt == 0 && IsSignatureRejected(err) {
+			continue
+		}
+
+		return alias, err
+	}
+}
+
+// load fetches the alias options when needed, r.mu must be held
+func (r *SuffixResolver) load(refresh bool) error {
+	if r.options == nil || refresh || (r.ttl > 0 && time.Since(r.fetchedAt) > r.ttl) {
+		options, err := r.client.GetAliasOptions(r.hostname)
+		if err != nil {
+			return err
+		}
+		r.options = options
+		r.fetchedAt = time.Now()
+	}
+
+	if !r.options.CanCreate {
+		return errors.New("this account cannot create more aliases")
+	}
+
+	return nil
+}
+
+// find returns a copy of the first option matching
+func (r *SuffixResolver) find(suffix string, refresh bool, match func(AliasOptionsSuffix) bool) (*AliasOptionsSuffix, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.load(refresh); err != nil {
+		return nil, err
+	}
+
+	for _, option := range r.options.Suffixes {
+		if match(option) {
+			return &option, nil
+		}
+	}
+
+	return nil, fmt.Errorf("suffix %s is not available (available: %s)", suffix, r.available())
+}
+
+// available lists the suffixes of the options, r.mu must be held
+func (r *SuffixResolver) available() string {
+	names := make([]string, len(r.options.Suffixes))
+	for i, suffix := range r.options.Suffixes {
+		names[i] = suffix.Suffix
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,182 @@
+package simplelogin
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSuffixResolver_Create(t *testing.T) {
+	var optionsCalls, createCalls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/alias/options":
+			optionsCalls++
+			json.NewEncoder(w).Encode(AliasOptions{
+				CanCreate: true,
+				Suffixes: []AliasOptionsSuffix{
+					{Suffix: "@example.com", SignedSuffix: "signed-" + string(rune('0'+optionsCalls)), IsCustom: true},
+					{Suffix: ".abc@aleeas.com", SignedSuffix: "other"},
+				},
+			})
+		case "/v3/alias/custom/new":
+			createCalls++
+			var input AliasCreateCustomOptions
+			json.NewDecoder(r.Body).Decode(&input)
+			if input.AliasPrefix != "shop" {
+				t.Errorf("prefix = %q, want shop", input.AliasPrefix)
+			}
+
+			// The first signature has expired
+			if input.SignedSuffix == "signed-1" {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				json.NewEncoder(w).Encode(map[string]string{"error": "Alias creation time has expired"})
+				return
+			}
+			json.NewEncoder(w).Encode(Alias{ID: 7, Email: "shop@example.com"})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&server.URL, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := NewSuffixResolver(client, "", 0)
+
+	alias, err := resolver.Create("Shop@example.com", AliasCreateCustomOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.ID != 7 {
+		t.Errorf("Create() = %+v", alias)
+	}
+	if optionsCalls != 2 || createCalls != 2 {
+		t.Errorf("got %d options and %d create calls, want 2 and 2", optionsCalls, createCalls)
+	}
+
+	if _, _, err := resolver.Resolve("shop@unknown.org", false); err == nil {
+		t.Error("Resolve() of an unavailable suffix expected an error")
+	}
+	if optionsCalls != 2 {
+		t.Errorf("Resolve() refetched the options, %d calls", optionsCalls)
+	}
+}
+
+func TestIsSignatureRejected(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{err: &APIError{StatusCode: http.StatusPreconditionFailed}, want: true},
+		{err: &APIError{StatusCode: http.StatusBadRequest, Message: "suffix not available"}},
+		{err: &APIError{StatusCode: http.StatusBadRequest, Message: "domain expired"}},
+		{err: nil},
+	}
+
+	for _, tt := range tests {
+		if got := IsSignatureRejected(tt.err); got != tt.want {
+			t.Errorf("IsSignatureRejected(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestSuffixResolver_CreateWithSuffix(t *testing.T) {
+	var optionsCalls int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/alias/options":
+			optionsCalls++
+			json.NewEncoder(w).Encode(AliasOptions{
+				CanCreate: true,
+				Suffixes: []AliasOptionsSuffix{
+					{Suffix: "@example.com", SignedSuffix: "domain", IsCustom: true},
+					{Suffix: ".abc@example.com", SignedSuffix: "random"},
+				},
+			})
+		case "/v3/alias/custom/new":
+			var input AliasCreateCustomOptions
+			json.NewDecoder(r.Body).Decode(&input)
+			json.NewEncoder(w).Encode(Alias{ID: 8, Email: input.AliasPrefix + "|" + input.SignedSuffix})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&server.URL, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := NewSuffixResolver(client, "", 0)
+
+	// shop.abc@example.com also ends with the longer .abc@example.com suffix
+	alias, err := resolver.CreateWithSuffix("shop.abc", "@Example.com", AliasCreateCustomOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alias.Email != "shop.abc|domain" {
+		t.Errorf("CreateWithSuffix() created %q, want shop.abc|domain", alias.Email)
+	}
+
+	if _, err := resolver.ResolveSuffix("@unknown.org", false); err == nil {
+		t.Error("ResolveSuffix() of an unavailable suffix expected an error")
+	}
+	if optionsCalls != 1 {
+		t.Errorf("got %d options calls, want 1", optionsCalls)
+	}
+}
+
+func TestSuffixResolver_CreateWithOption(t *testing.T) {
+	var signed []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v5/alias/options":
+			json.NewEncoder(w).Encode(AliasOptions{
+				CanCreate: true,
+				Suffixes: []AliasOptionsSuffix{
+					{Suffix: "@example.com", SignedSuffix: "premium-2", IsPremium: true},
+					{Suffix: "@example.com", SignedSuffix: "free-2"},
+				},
+			})
+		case "/v3/alias/custom/new":
+			var input AliasCreateCustomOptions
+			json.NewDecoder(r.Body).Decode(&input)
+			signed = append(signed, input.SignedSuffix)
+
+			// The signature of the option picked earlier has expired
+			if input.SignedSuffix == "free-1" {
+				w.WriteHeader(http.StatusPreconditionFailed)
+				json.NewEncoder(w).Encode(map[string]string{"error": "Alias creation time has expired"})
+				return
+			}
+			json.NewEncoder(w).Encode(Alias{ID: 9, Email: input.AliasPrefix + "@example.com"})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewClient(&server.URL, "test-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolver := NewSuffixResolver(client, "", 0)
+	option := AliasOptionsSuffix{Suffix: "@example.com", SignedSuffix: "free-1"}
+
+	if _, err := resolver.CreateWithOption("shop", option, AliasCreateCustomOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(signed) != 2 || signed[0] != "free-1" || signed[1] != "free-2" {
+		t.Errorf("signed suffixes sent = %v, want [free-1 free-2]", signed)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 type UserInfo struct {
@@ -57,3 +59,39 @@ func (c *Client) UpdateUserInfo(userInfo UserInfoUpdate) (*UserInfo, error) {
 
 	return &result, nil
 }
+
+// CookieTokenResponse represents the response for the cookie token endpoint
+type CookieTokenResponse struct {
+	Token string `json:"token"`
+}
+
+// GetCookieToken exchanges the API key for a short-lived token that signs
+// the user in to the web dashboard
+func (c *Client) GetCookieToken() (string, error) {
+	endpoint := "/user/cookie_token"
+
+	resp, err := c.doRequest(http.MethodPost, endpoint, nil)
+	if err != nil {
+		return "", err
+	}
+
+	var result CookieTokenResponse
+	if err := c.handleResponse(resp, &result); err != nil {
+		return "", err
+	}
+
+	return result.Token, nil
+}
+
+// DashboardSignInURL returns the URL that signs the user in to the web dashboard with a cookie token
+func (c *Client) DashboardSignInURL(token, next string) string {
+	webURL := strings.TrimSuffix(strings.TrimSuffix(c.baseURL, "/"), "/api")
+
+	values := url.Values{}
+	values.Set("token", token)
+	if next != "" {
+		values.Set("next", next)
+	}
+
+	return fmt.Sprintf("%s/auth/api_to_cookie?%s", webURL, values.Encode())
+}